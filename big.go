@@ -0,0 +1,562 @@
+package variant
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+var _ IConvertStrategy[*big.Int] = (*bigIntConverter)(nil)
+
+type bigIntConverter struct {
+	Converter[*big.Int]
+}
+
+func (c bigIntConverter) FromString(v Variant) (*big.Int, error) {
+	s, err := v.numeral()
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	b, err := parseBigInt(s, v.options())
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return b, nil
+}
+
+func (c bigIntConverter) FromBool(v Variant) (*big.Int, error) {
+	if len(v.Data) == 0 || v.Data[0] == 0x00 {
+		return new(big.Int), nil
+	}
+	return big.NewInt(1), nil
+}
+
+func (c bigIntConverter) FromInt(v Variant) (*big.Int, error) {
+	i, err := payloadInt(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return big.NewInt(i), nil
+}
+
+func (c bigIntConverter) FromInt8(v Variant) (*big.Int, error) {
+	i, err := payloadInt8(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return big.NewInt(int64(i)), nil
+}
+
+func (c bigIntConverter) FromInt16(v Variant) (*big.Int, error) {
+	i, err := payloadInt16(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return big.NewInt(int64(i)), nil
+}
+
+func (c bigIntConverter) FromInt32(v Variant) (*big.Int, error) {
+	i, err := payloadInt32(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return big.NewInt(int64(i)), nil
+}
+
+func (c bigIntConverter) FromInt64(v Variant) (*big.Int, error) {
+	i, err := payloadInt64(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return big.NewInt(i), nil
+}
+
+func (c bigIntConverter) FromUint(v Variant) (*big.Int, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return new(big.Int).SetUint64(i), nil
+}
+
+func (c bigIntConverter) FromUint8(v Variant) (*big.Int, error) {
+	i, err := payloadUint8(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return new(big.Int).SetUint64(uint64(i)), nil
+}
+
+func (c bigIntConverter) FromUint16(v Variant) (*big.Int, error) {
+	i, err := payloadUint16(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return new(big.Int).SetUint64(uint64(i)), nil
+}
+
+func (c bigIntConverter) FromUint32(v Variant) (*big.Int, error) {
+	i, err := payloadUint32(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return new(big.Int).SetUint64(uint64(i)), nil
+}
+
+func (c bigIntConverter) FromUint64(v Variant) (*big.Int, error) {
+	i, err := payloadUint64(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return new(big.Int).SetUint64(i), nil
+}
+
+func (c bigIntConverter) FromUintptr(v Variant) (*big.Int, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return new(big.Int).SetUint64(i), nil
+}
+
+func (c bigIntConverter) FromFloat32(v Variant) (*big.Int, error) {
+	f, err := payloadFloat32(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	r, err := bigIntOfFloat(v, float64(f))
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return r, nil
+}
+
+func (c bigIntConverter) FromFloat64(v Variant) (*big.Int, error) {
+	f, err := payloadFloat64(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	r, err := bigIntOfFloat(v, f)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return r, nil
+}
+
+func (c bigIntConverter) FromComplex64(v Variant) (*big.Int, error) {
+	z, err := payloadComplex64(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	if imag(z) != 0 && v.options().Strict {
+		return nil, conversionError(v, BigInt, ErrInexact)
+	}
+	r, err := bigIntOfFloat(v, float64(real(z)))
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return r, nil
+}
+
+func (c bigIntConverter) FromComplex128(v Variant) (*big.Int, error) {
+	z, err := payloadComplex128(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	if imag(z) != 0 && v.options().Strict {
+		return nil, conversionError(v, BigInt, ErrInexact)
+	}
+	r, err := bigIntOfFloat(v, real(z))
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return r, nil
+}
+
+func (c bigIntConverter) FromTime(v Variant) (*big.Int, error) {
+	t, err := payloadTime(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return big.NewInt(epochNumber(v, t)), nil
+}
+
+func (c bigIntConverter) FromDuration(v Variant) (*big.Int, error) {
+	d, err := payloadDuration(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	n, err := durationNumber(v, d)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return big.NewInt(n), nil
+}
+
+func (c bigIntConverter) FromBigInt(v Variant) (*big.Int, error) {
+	b, err := payloadBigInt(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return b, nil
+}
+
+func (c bigIntConverter) FromBigFloat(v Variant) (*big.Int, error) {
+	b, err := payloadBigFloatInt(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return b, nil
+}
+
+func (c bigIntConverter) FromDecimal(v Variant) (*big.Int, error) {
+	b, err := payloadDecInt(v)
+	if err != nil {
+		return nil, conversionError(v, BigInt, err)
+	}
+	return b, nil
+}
+
+func newBigIntConverter() IConvertStrategy[*big.Int] {
+	c := &bigIntConverter{}
+	c.m = map[Kind]func(v Variant) (*big.Int, error){
+		String:     c.FromString,
+		Bool:       c.FromBool,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Time:       c.FromTime,
+		Duration:   c.FromDuration,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
+	}
+	return c
+}
+
+var _ IConvertStrategy[*big.Float] = (*bigFloatConverter)(nil)
+
+type bigFloatConverter struct {
+	Converter[*big.Float]
+}
+
+func (c bigFloatConverter) FromString(v Variant) (*big.Float, error) {
+	b, err := v.numeral()
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	f, err := parseBigFloat(*(*string)(unsafe.Pointer(&b)))
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return f, nil
+}
+
+func (c bigFloatConverter) FromBool(v Variant) (*big.Float, error) {
+	if len(v.Data) == 0 || v.Data[0] == 0x00 {
+		return new(big.Float), nil
+	}
+	return big.NewFloat(1), nil
+}
+
+func (c bigFloatConverter) FromInt(v Variant) (*big.Float, error) {
+	i, err := payloadInt(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return new(big.Float).SetInt64(i), nil
+}
+
+func (c bigFloatConverter) FromInt8(v Variant) (*big.Float, error) {
+	i, err := payloadInt8(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return new(big.Float).SetInt64(int64(i)), nil
+}
+
+func (c bigFloatConverter) FromInt16(v Variant) (*big.Float, error) {
+	i, err := payloadInt16(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return new(big.Float).SetInt64(int64(i)), nil
+}
+
+func (c bigFloatConverter) FromInt32(v Variant) (*big.Float, error) {
+	i, err := payloadInt32(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return new(big.Float).SetInt64(int64(i)), nil
+}
+
+func (c bigFloatConverter) FromInt64(v Variant) (*big.Float, error) {
+	i, err := payloadInt64(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return new(big.Float).SetInt64(i), nil
+}
+
+func (c bigFloatConverter) FromUint(v Variant) (*big.Float, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return new(big.Float).SetUint64(i), nil
+}
+
+func (c bigFloatConverter) FromUint8(v Variant) (*big.Float, error) {
+	i, err := payloadUint8(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return new(big.Float).SetUint64(uint64(i)), nil
+}
+
+func (c bigFloatConverter) FromUint16(v Variant) (*big.Float, error) {
+	i, err := payloadUint16(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return new(big.Float).SetUint64(uint64(i)), nil
+}
+
+func (c bigFloatConverter) FromUint32(v Variant) (*big.Float, error) {
+	i, err := payloadUint32(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return new(big.Float).SetUint64(uint64(i)), nil
+}
+
+func (c bigFloatConverter) FromUint64(v Variant) (*big.Float, error) {
+	i, err := payloadUint64(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return new(big.Float).SetUint64(i), nil
+}
+
+func (c bigFloatConverter) FromUintptr(v Variant) (*big.Float, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return new(big.Float).SetUint64(i), nil
+}
+
+func (c bigFloatConverter) FromFloat32(v Variant) (*big.Float, error) {
+	f, err := payloadFloat32(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	r, err := bigFloatOf(float64(f))
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return r, nil
+}
+
+func (c bigFloatConverter) FromFloat64(v Variant) (*big.Float, error) {
+	f, err := payloadFloat64(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	r, err := bigFloatOf(f)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return r, nil
+}
+
+func (c bigFloatConverter) FromComplex64(v Variant) (*big.Float, error) {
+	z, err := payloadComplex64(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	r, err := bigFloatOf(float64(real(z)))
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return r, nil
+}
+
+func (c bigFloatConverter) FromComplex128(v Variant) (*big.Float, error) {
+	z, err := payloadComplex128(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	r, err := bigFloatOf(real(z))
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return r, nil
+}
+
+func (c bigFloatConverter) FromTime(v Variant) (*big.Float, error) {
+	t, err := payloadTime(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return parseBigFloat(epochDec(v, t).String())
+}
+
+func (c bigFloatConverter) FromDuration(v Variant) (*big.Float, error) {
+	d, err := payloadDuration(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return new(big.Float).Quo(new(big.Float).SetInt64(int64(d)), new(big.Float).SetInt64(int64(v.durationUnit()))), nil
+}
+
+func (c bigFloatConverter) FromBigInt(v Variant) (*big.Float, error) {
+	b, err := payloadBigInt(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return new(big.Float).SetPrec(bigIntPrec(b)).SetInt(b), nil
+}
+
+func (c bigFloatConverter) FromBigFloat(v Variant) (*big.Float, error) {
+	f, err := payloadBigFloat(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return f, nil
+}
+
+func (c bigFloatConverter) FromDecimal(v Variant) (*big.Float, error) {
+	d, err := payloadDec(v)
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	f, err := parseBigFloat(d.String())
+	if err != nil {
+		return nil, conversionError(v, BigFloat, err)
+	}
+	return f, nil
+}
+
+func newBigFloatConverter() IConvertStrategy[*big.Float] {
+	c := &bigFloatConverter{}
+	c.m = map[Kind]func(v Variant) (*big.Float, error){
+		String:     c.FromString,
+		Bool:       c.FromBool,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Time:       c.FromTime,
+		Duration:   c.FromDuration,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
+	}
+	return c
+}
+
+// bigFloatPrec returns a precision, in bits, large enough to hold the value of
+// the decimal literal s exactly when it is an integer, which takes about four
+// bits per digit including those implied by a positive exponent. The exponent
+// counts for at most 1024 digits.
+func bigFloatPrec(s string) uint {
+	digits := len(s)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		if exp, err := strconv.Atoi(s[i+1:]); err == nil && exp > 0 {
+			digits += min(exp, 1024)
+		}
+	}
+	return max(64, uint(digits)*4)
+}
+
+// bigIntPrec returns the precision, in bits, needed to hold b exactly.
+func bigIntPrec(b *big.Int) uint {
+	return max(64, uint(b.BitLen()))
+}
+
+// bigFloatInt returns f rounded to an integer with the rounding mode of o.
+// Infinities are reported as ErrOverflow, and fractional values as ErrInexact
+// if o is strict.
+func bigFloatInt(f *big.Float, o *Options) (*big.Int, error) {
+	if f.IsInf() {
+		return nil, ErrOverflow
+	}
+	b, acc := f.Int(nil)
+	switch {
+	case acc == big.Exact:
+		return b, nil
+	case o.Strict:
+		return nil, ErrInexact
+	}
+	// the fractional part fits in the precision of f
+	frac := new(big.Float).SetPrec(f.Prec()).Sub(f, new(big.Float).SetInt(b))
+	half := frac.Abs(frac).Cmp(big.NewFloat(0.5))
+	if roundAway(o.Rounding, f.Signbit(), half, b.Bit(0) == 1) {
+		if f.Signbit() {
+			b.Sub(b, big.NewInt(1))
+		} else {
+			b.Add(b, big.NewInt(1))
+		}
+	}
+	return b, nil
+}
+
+// parseBigFloat parses s as a decimal floating-point number of arbitrary size
+// and precision.
+func parseBigFloat(s string) (*big.Float, error) {
+	f, _, err := big.ParseFloat(s, 10, bigFloatPrec(s), big.ToNearestEven)
+	if err != nil {
+		return nil, ErrSyntax
+	}
+	return f, nil
+}
+
+// bigIntOfFloat returns f rounded with the rounding mode of v. NaN and
+// infinities are reported as ErrOverflow, fractional values as ErrInexact if
+// v is strict.
+func bigIntOfFloat(v Variant, f float64) (*big.Int, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, ErrOverflow
+	}
+	r := roundFloat(v, f)
+	if r != f && v.options().Strict {
+		return nil, ErrInexact
+	}
+	b, _ := big.NewFloat(r).Int(nil)
+	return b, nil
+}
+
+// bigFloatOf returns f as a *big.Float. NaN is reported as ErrOverflow.
+func bigFloatOf(f float64) (*big.Float, error) {
+	if math.IsNaN(f) {
+		return nil, ErrOverflow
+	}
+	return big.NewFloat(f), nil
+}
@@ -0,0 +1,109 @@
+package variant
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestVariant_BigInt(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	v := New(huge)
+	assert(v.Type == BigInt)
+	assert(v.ToString() == "123456789012345678901234567890")
+	assert(v.ToBigInt().Cmp(huge) == 0)
+	assert(v.ToBigFloat().Text('f', 0) == "123456789012345678901234567890")
+	assert(v.ToFloat64() == 1.2345678901234568e29)
+
+	for _, try := range []func() error{
+		func() error { _, err := v.TryInt(); return err },
+		func() error { _, err := v.TryInt64(); return err },
+		func() error { _, err := v.TryUint(); return err },
+		func() error { _, err := v.TryUint64(); return err },
+		func() error { _, err := v.TryInt8(); return err },
+		func() error { _, err := v.TryTime(); return err },
+		func() error { _, err := v.TryDuration(); return err },
+		func() error { _, err := New(new(big.Int).Lsh(huge, 1000)).TryFloat64(); return err },
+		func() error { _, err := New(big.NewInt(-1)).TryUint64(); return err },
+	} {
+		assert(errors.Is(try(), ErrOverflow))
+	}
+
+	small := New(big.NewInt(-42))
+	assert(small.ToInt() == -42)
+	assert(small.ToInt64() == -42)
+	assert(small.ToInt8() == -42)
+	assert(small.ToFloat32() == -42)
+	assert(small.ToComplex128() == -42)
+	assert(small.ToTime().Equal(time.Unix(0, -42)))
+	assert(New(new(big.Int).SetUint64(math.MaxUint64)).ToUint64() == math.MaxUint64)
+	assert(New((*big.Int)(nil)).Type == BigInt && New((*big.Int)(nil)).IsNull())
+}
+
+func TestVariant_BigFloat(t *testing.T) {
+	f, _, _ := big.ParseFloat("12345.678", 10, 128, big.ToNearestEven)
+	v := New(f)
+	assert(v.Type == BigFloat)
+	assert(v.ToString() == "12345.678", v.ToString())
+	assert(v.ToInt() == 12345)
+	assert(v.ToUint64() == 12345)
+	assert(v.ToFloat64() == 12345.678)
+	assert(v.ToBigInt().Int64() == 12345)
+	assert(v.ToBigFloat().Cmp(f) == 0)
+
+	inf := New(new(big.Float).SetInf(false))
+	_, err := inf.TryInt64()
+	assert(errors.Is(err, ErrOverflow), err)
+	_, err = New(mustBigFloat("1e400")).TryFloat64()
+	assert(errors.Is(err, ErrOverflow), err)
+	_, err = New(mustBigFloat("-1e400")).TryFloat32()
+	assert(errors.Is(err, ErrOverflow), err)
+	_, err = json.Marshal(inf)
+	assert(err != nil)
+}
+
+func TestVariant_ToBigInt(t *testing.T) {
+	targets := []Pair[string]{
+		{"123456789012345678901234567890", "123456789012345678901234567890"},
+		{"-12.9", "-12"},
+		{"1e30", "1000000000000000000000000000000"},
+		{true, "1"},
+		{int8(-8), "-8"},
+		{uint64(math.MaxUint64), "18446744073709551615"},
+		{-3.9, "-3"},
+		{complex(2.5, 1), "2"},
+		{time.Duration(86), "86"},
+	}
+	for _, pair := range targets {
+		t.Run("ToBigInt", func(t *testing.T) {
+			assert(New(pair.Key).ToBigInt().String() == pair.Val, pair.Key)
+		})
+	}
+	_, err := New("abc").TryBigInt()
+	assert(errors.Is(err, ErrSyntax), err)
+	_, err = New(math.Inf(1)).TryBigInt()
+	assert(errors.Is(err, ErrOverflow), err)
+	assert(New("abc").ToBigInt() == nil)
+}
+
+func TestVariant_ToBigFloat(t *testing.T) {
+	targets := []Pair[string]{
+		{"0.10000000000000000001", "0.10000000000000000001"},
+		{"-1e400", "-1e+400"},
+		{false, "0"},
+		{int64(math.MinInt64), "-9.223372036854775808e+18"},
+		{1.5, "1.5"},
+		{90 * time.Second, "9e+10"},
+	}
+	for _, pair := range targets {
+		t.Run("ToBigFloat", func(t *testing.T) {
+			assert(New(pair.Key).ToBigFloat().Text('g', -1) == pair.Val, pair.Key)
+		})
+	}
+	_, err := New(math.NaN()).TryBigFloat()
+	assert(errors.Is(err, ErrOverflow), err)
+	assert(To[*big.Float](New("2.5")).Cmp(big.NewFloat(2.5)) == 0)
+}
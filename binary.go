@@ -0,0 +1,438 @@
+package variant
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// The binary format written by MarshalBinary, version 1, is a version byte
+// followed by a value:
+//
+//	variant = 0x01 value
+//	value   = tag [layout] body
+//	tag     = kind | 0x80 if the value is a typed null | 0x40 if a layout follows
+//	layout  = uvarint(len) bytes
+//
+// A typed null has no body, neither has an Invalid value. Integers are
+// written as varints regardless of their size in memory, so that an Int
+// encoded on a 64-bit platform decodes on a 32-bit one when it fits:
+//
+//	Bool                                 1 byte, 0 or 1
+//	Int, Int8, Int16, Int32, Int64       zig-zag varint
+//	Duration                             zig-zag varint, in nanoseconds
+//	Uint, Uint8, Uint16, Uint32, Uint64  uvarint
+//	Uintptr                              uvarint
+//	Float32, Float64                     IEEE 754 bits, 4 or 8 bytes big-endian
+//	Complex64, Complex128                real then imaginary part, as floats
+//	String, Bytes                        uvarint(len) bytes
+//	Time                                 uvarint(len) time.Time.MarshalBinary
+//	BigInt, BigFloat                     uvarint(len) GobEncode
+//	Decimal                              uvarint(len) 4-byte scale, GobEncode of the unscaled value
+//	List                                 uvarint(n) value*n
+//	Map                                  uvarint(n) (uvarint(len) key value)*n
+//
+// Map entries keep their order. The layout is only written when it differs
+// from time.DateTime.
+
+const binaryVersion = 1
+
+// maxDepth bounds the nesting of the lists and maps the decoders read, so
+// that untrusted input cannot exhaust the stack, as in encoding/json.
+const maxDepth = 10000
+
+// errDepth is reported for input nested deeper than maxDepth.
+var errDepth = errors.New("variant: exceeded max depth")
+
+const (
+	binaryNull   = 0x80
+	binaryLayout = 0x40
+	binaryKind   = 0x3f
+)
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (v Variant) MarshalBinary() ([]byte, error) {
+	return v.AppendBinary(nil)
+}
+
+// AppendBinary appends the binary encoding of v to b, see MarshalBinary. It
+// does not allocate when b has enough capacity, so encoding a batch of
+// variants into a reused buffer is allocation-free.
+func (v Variant) AppendBinary(b []byte) ([]byte, error) {
+	return v.appendValue(append(b, binaryVersion))
+}
+
+func (v Variant) appendValue(b []byte) ([]byte, error) {
+	tag := byte(v.Type)
+	if v.null {
+		tag |= binaryNull
+	}
+	if v.layout != time.DateTime {
+		tag |= binaryLayout
+	}
+	b = append(b, tag)
+	if v.layout != time.DateTime {
+		b = appendString(b, v.layout)
+	}
+	if v.null || v.Type == Invalid {
+		return b, nil
+	}
+
+	switch v.Type {
+	case Bool:
+		if len(v.Data) > 0 && v.Data[0] != 0 {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+	case Int, Int8, Int16, Int32, Int64, Duration:
+		i, err := binaryInt(v)
+		if err != nil {
+			return nil, conversionError(v, Bytes, err)
+		}
+		return binary.AppendVarint(b, i), nil
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		u, err := binaryUint(v)
+		if err != nil {
+			return nil, conversionError(v, Bytes, err)
+		}
+		return binary.AppendUvarint(b, u), nil
+	case Float32, Float64, Complex64, Complex128:
+		switch size := binaryFixedSize(v.Type); len(v.Data) {
+		case 0:
+			// an empty payload is zero
+			return append(b, make([]byte, size)...), nil
+		case size:
+			return append(b, v.Data...), nil
+		}
+		return nil, conversionError(v, Bytes, ErrTruncated)
+	case String, Bytes, Time, BigInt, BigFloat, Decimal:
+		b = binary.AppendUvarint(b, uint64(len(v.Data)))
+		return append(b, v.Data...), nil
+	case List:
+		b = binary.AppendUvarint(b, uint64(len(v.list)))
+		for _, elem := range v.list {
+			var err error
+			if b, err = elem.appendValue(b); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case Map:
+		if v.obj == nil {
+			return append(b, 0), nil
+		}
+		b = binary.AppendUvarint(b, uint64(len(v.obj.keys)))
+		for _, key := range v.obj.keys {
+			var err error
+			b = appendString(b, key)
+			if b, err = v.obj.m[key].appendValue(b); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, conversionError(v, Bytes, ErrUnsupported)
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// binaryInt returns the value of a signed integer or Duration variant.
+func binaryInt(v Variant) (int64, error) {
+	switch v.Type {
+	case Int:
+		return payloadInt(v)
+	case Int8:
+		i, err := payloadInt8(v)
+		return int64(i), err
+	case Int16:
+		i, err := payloadInt16(v)
+		return int64(i), err
+	case Int32:
+		i, err := payloadInt32(v)
+		return int64(i), err
+	case Duration:
+		d, err := payloadDuration(v)
+		return int64(d), err
+	}
+	return payloadInt64(v)
+}
+
+// binaryUint returns the value of an unsigned integer variant.
+func binaryUint(v Variant) (uint64, error) {
+	switch v.Type {
+	case Uint8:
+		u, err := payloadUint8(v)
+		return uint64(u), err
+	case Uint16:
+		u, err := payloadUint16(v)
+		return uint64(u), err
+	case Uint32:
+		u, err := payloadUint32(v)
+		return uint64(u), err
+	case Uint64:
+		return payloadUint64(v)
+	}
+	return payloadUint(v)
+}
+
+// binaryFixedSize returns the size of the payload of a float or complex kind.
+func binaryFixedSize(k Kind) int {
+	switch k {
+	case Float32:
+		return 4
+	case Float64, Complex64:
+		return 8
+	}
+	return 16
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. data
+// must hold exactly one variant.
+func (v *Variant) UnmarshalBinary(data []byte) error {
+	r, rest, err := ConsumeBinary(data)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return errors.New("variant: trailing data after binary variant")
+	}
+	*v = r
+	return nil
+}
+
+// ConsumeBinary decodes the variant at the start of data, as written by
+// AppendBinary, and returns it along with the remaining bytes. It reads a
+// batch of variants appended to the same buffer one after the other.
+func ConsumeBinary(data []byte) (Variant, []byte, error) {
+	if len(data) == 0 {
+		return Nil, nil, ErrTruncated
+	}
+	if data[0] != binaryVersion {
+		return Nil, nil, fmt.Errorf("variant: unsupported binary version %d", data[0])
+	}
+	d := binaryDecoder{data: data[1:]}
+	v, err := d.value()
+	if err != nil {
+		return Nil, nil, err
+	}
+	return v, d.data, nil
+}
+
+type binaryDecoder struct {
+	data  []byte
+	depth int // the nesting of the value being decoded
+}
+
+func (d *binaryDecoder) uvarint() (uint64, error) {
+	u, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		return 0, ErrTruncated
+	}
+	d.data = d.data[n:]
+	return u, nil
+}
+
+func (d *binaryDecoder) varint() (int64, error) {
+	i, n := binary.Varint(d.data)
+	if n <= 0 {
+		return 0, ErrTruncated
+	}
+	d.data = d.data[n:]
+	return i, nil
+}
+
+func (d *binaryDecoder) bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)) {
+		return nil, ErrTruncated
+	}
+	b := d.data[:n:n]
+	d.data = d.data[n:]
+	return b, nil
+}
+
+// lenBytes reads a length-prefixed byte string, which aliases the input.
+func (d *binaryDecoder) lenBytes() ([]byte, error) {
+	n, err := d.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	return d.bytes(n)
+}
+
+func (d *binaryDecoder) value() (Variant, error) {
+	if len(d.data) == 0 {
+		return Nil, ErrTruncated
+	}
+	tag := d.data[0]
+	d.data = d.data[1:]
+	k := Kind(tag & binaryKind)
+	if int(k) >= len(kindNames) || k == Interface {
+		return Nil, fmt.Errorf("variant: unknown kind %d in binary variant", k)
+	}
+	layout := time.DateTime
+	if tag&binaryLayout != 0 {
+		b, err := d.lenBytes()
+		if err != nil {
+			return Nil, err
+		}
+		layout = string(b)
+	}
+	if tag&binaryNull != 0 || k == Invalid {
+		v := NewNull(k)
+		v.layout = layout
+		return v, nil
+	}
+	if d.depth++; d.depth > maxDepth {
+		return Nil, errDepth
+	}
+	v, err := d.body(k)
+	d.depth--
+	if err != nil {
+		return Nil, err
+	}
+	v.layout = layout
+	return v, nil
+}
+
+func (d *binaryDecoder) body(k Kind) (Variant, error) {
+	switch k {
+	case Bool:
+		b, err := d.bytes(1)
+		if err != nil {
+			return Nil, err
+		}
+		return New(b[0] != 0), nil
+	case Int, Int8, Int16, Int32, Int64, Duration:
+		i, err := d.varint()
+		if err != nil {
+			return Nil, err
+		}
+		return newBinaryInt(k, i)
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		u, err := d.uvarint()
+		if err != nil {
+			return Nil, err
+		}
+		return newBinaryUint(k, u)
+	case Float32, Float64, Complex64, Complex128:
+		b, err := d.bytes(uint64(binaryFixedSize(k)))
+		if err != nil {
+			return Nil, err
+		}
+		return Variant{Type: k, Data: clone(b)}, nil
+	case String, Bytes, Time, BigInt, BigFloat, Decimal:
+		b, err := d.lenBytes()
+		if err != nil {
+			return Nil, err
+		}
+		v := Variant{Type: k, Data: clone(b)}
+		if k == Decimal {
+			if _, err := payloadDec(v); err != nil {
+				return Nil, err
+			}
+		}
+		return v, nil
+	case List:
+		n, err := d.uvarint()
+		if err != nil {
+			return Nil, err
+		}
+		if n > uint64(len(d.data)) {
+			// every element takes at least one byte
+			return Nil, ErrTruncated
+		}
+		v := NewList()
+		v.list = make([]Variant, 0, min(n, 1024))
+		for range n {
+			elem, err := d.value()
+			if err != nil {
+				return Nil, err
+			}
+			v.list = append(v.list, elem)
+		}
+		return v, nil
+	case Map:
+		n, err := d.uvarint()
+		if err != nil {
+			return Nil, err
+		}
+		if n > uint64(len(d.data)) {
+			return Nil, ErrTruncated
+		}
+		v := NewMap()
+		for range n {
+			key, err := d.lenBytes()
+			if err != nil {
+				return Nil, err
+			}
+			elem, err := d.value()
+			if err != nil {
+				return Nil, err
+			}
+			v.obj.set(string(key), elem)
+		}
+		return v, nil
+	}
+	return Nil, fmt.Errorf("variant: unknown kind %d in binary variant", k)
+}
+
+// clone copies b, keeping an empty payload non-nil.
+func clone(b []byte) []byte {
+	return append(make([]byte, 0, len(b)), b...)
+}
+
+// newBinaryInt returns a variant of the signed integer kind k holding i, or
+// ErrOverflow if i does not fit, e.g. an Int written on a 64-bit platform and
+// read on a 32-bit one.
+func newBinaryInt(k Kind, i int64) (Variant, error) {
+	var fits bool
+	var v any
+	switch k {
+	case Int:
+		fits, v = i >= -maxInt-1 && i <= maxInt, int(i)
+	case Int8:
+		fits, v = i >= math.MinInt8 && i <= math.MaxInt8, int8(i)
+	case Int16:
+		fits, v = i >= math.MinInt16 && i <= math.MaxInt16, int16(i)
+	case Int32:
+		fits, v = i >= math.MinInt32 && i <= math.MaxInt32, int32(i)
+	case Int64:
+		fits, v = true, i
+	case Duration:
+		fits, v = true, time.Duration(i)
+	}
+	if !fits {
+		return Nil, &ConversionError{From: Int64, To: k, Err: ErrOverflow}
+	}
+	return New(v), nil
+}
+
+// newBinaryUint returns a variant of the unsigned integer kind k holding u, or
+// ErrOverflow if u does not fit.
+func newBinaryUint(k Kind, u uint64) (Variant, error) {
+	var fits bool
+	var v any
+	switch k {
+	case Uint:
+		fits, v = u <= maxUint, uint(u)
+	case Uint8:
+		fits, v = u <= math.MaxUint8, uint8(u)
+	case Uint16:
+		fits, v = u <= math.MaxUint16, uint16(u)
+	case Uint32:
+		fits, v = u <= math.MaxUint32, uint32(u)
+	case Uint64:
+		fits, v = true, u
+	case Uintptr:
+		fits, v = u <= maxUint, uintptr(u)
+	}
+	if !fits {
+		return Nil, &ConversionError{From: Uint64, To: k, Err: ErrOverflow}
+	}
+	return New(v), nil
+}
@@ -0,0 +1,161 @@
+package variant
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestVariant_MarshalBinary(t *testing.T) {
+	f, _, _ := big.ParseFloat("1.25", 10, 200, big.ToNearestEven)
+	tm := New(time.Date(2024, 5, 1, 10, 30, 0, 123, time.FixedZone("", 3600)))
+	tm.SetLayout(time.RFC3339)
+	var null *uint16
+	m := NewMap()
+	m.Set("z", 1).Set("a", []any{"x", 2.5}).Set("n", null)
+	values := []Variant{
+		Nil,
+		New(true),
+		New(-1),
+		New(int8(-8)),
+		New(int16(300)),
+		New(int32(math.MinInt32)),
+		New(int64(math.MaxInt64)),
+		New(uint(7)),
+		New(uint8(255)),
+		New(uint16(16)),
+		New(uint32(math.MaxUint32)),
+		New(uint64(math.MaxUint64)),
+		New(uintptr(9)),
+		New(float32(0.1)),
+		New(math.NaN()),
+		New(complex64(complex(1, -2))),
+		New(complex(1.5, 2.5)),
+		New(""),
+		New("héllo"),
+		New([]byte{0, 1, 2}),
+		tm,
+		New(-90 * time.Second),
+		New(new(big.Int).Lsh(big.NewInt(-1), 100)),
+		New(f),
+		New(NewDec(-1990, 3)),
+		New(null),
+		NewList(),
+		m,
+	}
+	for _, v := range values {
+		t.Run(v.Type.String(), func(t *testing.T) {
+			data, err := v.MarshalBinary()
+			assert(err == nil, v, err)
+			var got Variant
+			assert(got.UnmarshalBinary(data) == nil, v, data)
+			assert(equal(got, v), v, got)
+			assert(bytes.Equal(got.Data, v.Data), v, got)
+		})
+	}
+
+	// the order of map entries is kept
+	data, _ := m.MarshalBinary()
+	var got Variant
+	assert(got.UnmarshalBinary(data) == nil)
+	assert(got.Keys()[0] == "z" && got.Keys()[2] == "n")
+
+	// an int is a varint whatever its size in memory
+	data, _ = New(1).MarshalBinary()
+	assert(bytes.Equal(data, []byte{binaryVersion, byte(Int), 2}), data)
+	data, _ = New(int32(1)).MarshalBinary()
+	assert(bytes.Equal(data, []byte{binaryVersion, byte(Int32), 2}), data)
+	data, _ = Variant{Type: Int, Data: []byte{0, 0, 0, 1}, layout: time.DateTime}.MarshalBinary()
+	assert(bytes.Equal(data, []byte{binaryVersion, byte(Int), 2}), "32-bit payload", data)
+}
+
+func TestVariant_AppendBinary(t *testing.T) {
+	batch := []Variant{New(1), New("two"), New(3.0), NewList(4, "five")}
+	var buf []byte
+	for _, v := range batch {
+		var err error
+		buf, err = v.AppendBinary(buf)
+		assert(err == nil, err)
+	}
+	rest := buf
+	for _, want := range batch {
+		var v Variant
+		var err error
+		v, rest, err = ConsumeBinary(rest)
+		assert(err == nil, err)
+		assert(equal(v, want), v, want)
+	}
+	assert(len(rest) == 0)
+
+	buf = make([]byte, 0, 1024)
+	allocs := testing.AllocsPerRun(100, func() {
+		b := buf[:0]
+		for _, v := range batch {
+			b, _ = v.AppendBinary(b)
+		}
+	})
+	assert(allocs == 0, allocs)
+}
+
+func TestVariant_UnmarshalBinaryErrors(t *testing.T) {
+	valid, _ := New("abc").MarshalBinary()
+	for _, data := range [][]byte{
+		nil,
+		{2, byte(Int), 2},                       // unknown version
+		{binaryVersion, 0x3f},                   // unknown kind
+		{binaryVersion, byte(Int8), 0x80, 0x04}, // 256 does not fit in an int8
+		{binaryVersion, byte(Float64), 1, 2},    // short float
+		{binaryVersion, byte(List), 0xff, 0x01}, // more elements than bytes
+		{binaryVersion, byte(Decimal), 6, 0xc0, 0, 0, 0, 2, 1}, // scale -2^30
+		valid[:len(valid)-1], // short string
+		append(valid, 0),     // trailing data
+	} {
+		var v Variant
+		assert(v.UnmarshalBinary(data) != nil, data)
+	}
+	var v Variant
+	err := v.UnmarshalBinary([]byte{binaryVersion, byte(Int8), 0x80, 0x04})
+	assert(errors.Is(err, ErrOverflow), err)
+	err = v.UnmarshalBinary([]byte{binaryVersion, byte(Decimal), 6, 0xc0, 0, 0, 0, 2, 1})
+	assert(errors.Is(err, ErrOverflow), err)
+}
+
+func TestVariant_UnmarshalBinaryDepth(t *testing.T) {
+	// 4 MB of nested lists of one element
+	data := append([]byte{binaryVersion}, bytes.Repeat([]byte{byte(List), 1}, 2<<20)...)
+	var v Variant
+	err := v.UnmarshalBinary(data)
+	assert(errors.Is(err, errDepth), err)
+	_, _, err = ConsumeBinary(data)
+	assert(errors.Is(err, errDepth), err)
+
+	data = append([]byte{binaryVersion}, bytes.Repeat([]byte{byte(List), 1}, maxDepth-1)...)
+	data = append(data, byte(Map), 0)
+	assert(v.UnmarshalBinary(data) == nil)
+}
+
+func TestVariant_UnmarshalBinaryCount(t *testing.T) {
+	// 200 nested lists each claiming as many elements as the input has bytes
+	data := []byte{binaryVersion}
+	for range 200 {
+		data = binary.AppendUvarint(append(data, byte(List)), 100<<10)
+	}
+	data = append(data, make([]byte, 100<<10)...)
+	var v Variant
+	n := allocated(func() { v.UnmarshalBinary(data) })
+	assert(n < 256<<20, n)
+}
+
+// allocated returns the number of bytes f allocates.
+func allocated(f func()) uint64 {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	f()
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc
+}
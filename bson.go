@@ -0,0 +1,423 @@
+package variant
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MarshalBSON encodes the entries of a Map variant as a BSON document with
+// the following element types:
+//
+//	Invalid, typed nulls                  null
+//	Bool                                  boolean
+//	Int8, Int16, Int32, Uint8, Uint16     int32
+//	Int, Int64, Duration                  int64, a Duration in nanoseconds
+//	Uint, Uint32, Uint64, Uintptr         int64, or decimal128 beyond its range
+//	Float32, Float64                      double
+//	String                                string
+//	Bytes                                 binary, generic subtype
+//	Time                                  UTC datetime, to the millisecond
+//	BigInt                                int64, or decimal128 beyond its range
+//	Decimal                               decimal128
+//	Complex64, Complex128, BigFloat       string, their exact text form
+//	List                                  array
+//	Map                                   embedded document, keeping the order
+//
+// Numbers beyond the 34 digits and the exponent range of decimal128 are
+// reported as ErrOverflow.
+//
+// UnmarshalBSON decodes int32 elements as Int32, int64 as Int64, doubles as
+// Float64, decimal128 as Decimal, datetimes as a Time in UTC and binary of any
+// subtype as Bytes. Other element types, like ObjectId, are ErrUnsupported.
+
+const (
+	bsonDouble     = 0x01
+	bsonString     = 0x02
+	bsonDocument   = 0x03
+	bsonArray      = 0x04
+	bsonBinary     = 0x05
+	bsonBool       = 0x08
+	bsonDatetime   = 0x09
+	bsonNull       = 0x0a
+	bsonInt32      = 0x10
+	bsonInt64      = 0x12
+	bsonDecimal128 = 0x13
+
+	bsonBinaryOld = 0x02 // the binary subtype holding its own length
+
+	decimal128Bias   = 6176
+	decimal128MaxExp = 6111
+)
+
+// decimal128Max is the largest coefficient of a decimal128, 10^34-1.
+var decimal128Max = new(big.Int).Sub(pow10(34), big.NewInt(1))
+
+// MarshalBSON returns the BSON document holding the entries of v, which must
+// be a Map variant.
+func (v Variant) MarshalBSON() ([]byte, error) {
+	if v.Type != Map || v.null {
+		return nil, conversionError(v, Map, ErrUnsupported)
+	}
+	return appendBSONDocument(nil, v)
+}
+
+// appendBSONDocument appends the entries of a Map variant, or the elements of
+// a List variant keyed by their index, as a document.
+func appendBSONDocument(b []byte, v Variant) ([]byte, error) {
+	start := len(b)
+	b = append(b, 0, 0, 0, 0)
+	var err error
+	if v.Type == List {
+		for i, elem := range v.list {
+			if b, err = appendBSONElement(b, strconv.Itoa(i), elem); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		for _, key := range v.Keys() {
+			if strings.IndexByte(key, 0) >= 0 {
+				return nil, fmt.Errorf("variant: BSON key %q contains a NUL byte", key)
+			}
+			if b, err = appendBSONElement(b, key, v.obj.m[key]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	b = append(b, 0)
+	binary.LittleEndian.PutUint32(b[start:], uint32(len(b)-start))
+	return b, nil
+}
+
+// appendBSONElement appends the element named key holding v.
+func appendBSONElement(b []byte, key string, v Variant) ([]byte, error) {
+	typ := len(b)
+	b = append(append(b, 0), key...)
+	b = append(b, 0)
+	if v.null || v.Type == Invalid {
+		b[typ] = bsonNull
+		return b, nil
+	}
+	switch v.Type {
+	case Bool:
+		t, err := v.TryBool()
+		if err != nil {
+			return nil, err
+		}
+		b[typ] = bsonBool
+		if t {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+	case Int8, Int16, Int32, Uint8, Uint16:
+		i, err := v.TryInt32()
+		if err != nil {
+			return nil, err
+		}
+		b[typ] = bsonInt32
+		return binary.LittleEndian.AppendUint32(b, uint32(i)), nil
+	case Int, Int64, Duration:
+		i, err := binaryInt(v)
+		if err != nil {
+			return nil, conversionError(v, Int64, err)
+		}
+		b[typ] = bsonInt64
+		return binary.LittleEndian.AppendUint64(b, uint64(i)), nil
+	case Uint, Uint32, Uint64, Uintptr:
+		u, err := binaryUint(v)
+		if err != nil {
+			return nil, conversionError(v, Uint64, err)
+		}
+		if u > math.MaxInt64 {
+			b[typ] = bsonDecimal128
+			return appendBSONDecimal(b, v, NewDecFromBigInt(new(big.Int).SetUint64(u), 0))
+		}
+		b[typ] = bsonInt64
+		return binary.LittleEndian.AppendUint64(b, u), nil
+	case Float32, Float64:
+		f, err := v.TryFloat64()
+		if err != nil {
+			return nil, err
+		}
+		b[typ] = bsonDouble
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(f)), nil
+	case String:
+		b[typ] = bsonString
+		return appendBSONString(b, v.Data), nil
+	case Complex64, Complex128, BigFloat:
+		s, err := v.canonical().TryString()
+		if err != nil {
+			return nil, err
+		}
+		b[typ] = bsonString
+		return appendBSONString(b, []byte(s)), nil
+	case Bytes:
+		b[typ] = bsonBinary
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(v.Data)))
+		return append(append(b, 0), v.Data...), nil
+	case Time:
+		t, err := payloadTime(v)
+		if err != nil {
+			return nil, conversionError(v, Time, err)
+		}
+		b[typ] = bsonDatetime
+		return binary.LittleEndian.AppendUint64(b, uint64(t.UnixMilli())), nil
+	case BigInt:
+		x, err := payloadBigInt(v)
+		if err != nil {
+			return nil, conversionError(v, BigInt, err)
+		}
+		if x.IsInt64() {
+			b[typ] = bsonInt64
+			return binary.LittleEndian.AppendUint64(b, uint64(x.Int64())), nil
+		}
+		b[typ] = bsonDecimal128
+		return appendBSONDecimal(b, v, NewDecFromBigInt(x, 0))
+	case Decimal:
+		d, err := payloadDec(v)
+		if err != nil {
+			return nil, conversionError(v, Decimal, err)
+		}
+		b[typ] = bsonDecimal128
+		return appendBSONDecimal(b, v, d)
+	case List:
+		b[typ] = bsonArray
+		return appendBSONDocument(b, v)
+	case Map:
+		b[typ] = bsonDocument
+		return appendBSONDocument(b, v)
+	}
+	return nil, conversionError(v, Map, ErrUnsupported)
+}
+
+func appendBSONString(b, s []byte) []byte {
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(s)+1))
+	return append(append(b, s...), 0)
+}
+
+// appendBSONDecimal appends d, the value of v, as a decimal128.
+func appendBSONDecimal(b []byte, v Variant, d Dec) ([]byte, error) {
+	b, err := appendDecimal128(b, d)
+	if err != nil {
+		return nil, conversionError(v, Decimal, err)
+	}
+	return b, nil
+}
+
+// appendDecimal128 appends d in the IEEE 754 decimal128 format, with a binary
+// integer coefficient as BSON uses it. It returns ErrOverflow when d has more
+// than 34 digits or its exponent is out of range.
+func appendDecimal128(b []byte, d Dec) ([]byte, error) {
+	coef := new(big.Int).Abs(d.int())
+	exp := -int64(d.Scale())
+	if coef.Cmp(decimal128Max) > 0 || exp < -decimal128Bias || exp > decimal128MaxExp {
+		return nil, ErrOverflow
+	}
+	lo := new(big.Int).And(coef, new(big.Int).SetUint64(math.MaxUint64)).Uint64()
+	hi := new(big.Int).Rsh(coef, 64).Uint64()
+	hi |= uint64(exp+decimal128Bias) << 49
+	if d.Sign() < 0 {
+		hi |= 1 << 63
+	}
+	b = binary.LittleEndian.AppendUint64(b, lo)
+	return binary.LittleEndian.AppendUint64(b, hi), nil
+}
+
+// UnmarshalBSON decodes the BSON document in data into a Map variant.
+func (v *Variant) UnmarshalBSON(data []byte) error {
+	d := bsonDecoder{data: data}
+	r, err := d.document(false)
+	if err != nil {
+		return err
+	}
+	if len(d.data) > 0 {
+		return errors.New("variant: trailing data after BSON document")
+	}
+	*v = r
+	return nil
+}
+
+type bsonDecoder struct {
+	data  []byte
+	depth int // the nesting of the document being decoded
+}
+
+func (d *bsonDecoder) bytes(n int) ([]byte, error) {
+	if n < 0 || n > len(d.data) {
+		return nil, ErrTruncated
+	}
+	b := d.data[:n:n]
+	d.data = d.data[n:]
+	return b, nil
+}
+
+func (d *bsonDecoder) uint32() (uint32, error) {
+	b, err := d.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (d *bsonDecoder) uint64() (uint64, error) {
+	b, err := d.bytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func (d *bsonDecoder) cstring() (string, error) {
+	i := bytes.IndexByte(d.data, 0)
+	if i < 0 {
+		return "", ErrTruncated
+	}
+	s := string(d.data[:i])
+	d.data = d.data[i+1:]
+	return s, nil
+}
+
+// document decodes a document into a Map variant, or into a List variant
+// when it is the value of an array element.
+func (d *bsonDecoder) document(array bool) (Variant, error) {
+	if d.depth >= maxDepth {
+		return Nil, errDepth
+	}
+	size, err := d.uint32()
+	if err != nil {
+		return Nil, err
+	}
+	body, err := d.bytes(int(size) - 4)
+	if err != nil || len(body) == 0 || body[len(body)-1] != 0 {
+		return Nil, errors.New("variant: invalid BSON document size")
+	}
+	elems := bsonDecoder{data: body[:len(body)-1], depth: d.depth + 1}
+	v := NewMap()
+	if array {
+		v = NewList()
+	}
+	for len(elems.data) > 0 {
+		typ := elems.data[0]
+		elems.data = elems.data[1:]
+		key, err := elems.cstring()
+		if err != nil {
+			return Nil, err
+		}
+		elem, err := elems.element(typ)
+		if err != nil && (typ == bsonDocument || typ == bsonArray) {
+			// the error names the nested element, wrapping it again at
+			// every level would grow with the square of the depth
+			return Nil, err
+		}
+		if err != nil {
+			return Nil, fmt.Errorf("variant: BSON element %q: %w", key, err)
+		}
+		if array {
+			v.list = append(v.list, elem)
+		} else {
+			v.obj.set(key, elem)
+		}
+	}
+	return v, nil
+}
+
+// element decodes the value of an element of type typ.
+func (d *bsonDecoder) element(typ byte) (Variant, error) {
+	switch typ {
+	case bsonDouble:
+		u, err := d.uint64()
+		return New(math.Float64frombits(u)), err
+	case bsonString:
+		n, err := d.uint32()
+		if err != nil {
+			return Nil, err
+		}
+		b, err := d.bytes(int(n))
+		if err != nil {
+			return Nil, err
+		}
+		if len(b) == 0 || b[len(b)-1] != 0 {
+			return Nil, errors.New("invalid string")
+		}
+		return New(string(b[:len(b)-1])), nil
+	case bsonDocument, bsonArray:
+		return d.document(typ == bsonArray)
+	case bsonBinary:
+		n, err := d.uint32()
+		if err != nil {
+			return Nil, err
+		}
+		b, err := d.bytes(int(n) + 1)
+		if err != nil {
+			return Nil, err
+		}
+		subtype, b := b[0], b[1:]
+		if subtype == bsonBinaryOld && len(b) >= 4 {
+			b = b[4:]
+		}
+		return New(b), nil
+	case bsonBool:
+		b, err := d.bytes(1)
+		if err != nil {
+			return Nil, err
+		}
+		return New(b[0] != 0), nil
+	case bsonDatetime:
+		u, err := d.uint64()
+		return New(time.UnixMilli(int64(u)).UTC()), err
+	case bsonNull:
+		return NewNull(Invalid), nil
+	case bsonInt32:
+		u, err := d.uint32()
+		return New(int32(u)), err
+	case bsonInt64:
+		u, err := d.uint64()
+		return New(int64(u)), err
+	case bsonDecimal128:
+		lo, err := d.uint64()
+		if err != nil {
+			return Nil, err
+		}
+		hi, err := d.uint64()
+		if err != nil {
+			return Nil, err
+		}
+		dec, err := decimal128(hi, lo)
+		if err != nil {
+			return Nil, err
+		}
+		return New(dec), nil
+	}
+	return Nil, fmt.Errorf("type 0x%02x: %w", typ, ErrUnsupported)
+}
+
+// decimal128 returns the decimal the two halves of a decimal128 hold.
+// Infinities and NaN are ErrUnsupported.
+func decimal128(hi, lo uint64) (Dec, error) {
+	var exp int64
+	coef := new(big.Int)
+	switch {
+	case hi>>59&0xf == 0xf:
+		return Dec{}, fmt.Errorf("decimal128 infinity or NaN: %w", ErrUnsupported)
+	case hi>>61&0x3 == 0x3:
+		// the coefficient would exceed 10^34-1, such values are zero
+		exp = int64(hi>>47&0x3fff) - decimal128Bias
+	default:
+		exp = int64(hi>>49&0x3fff) - decimal128Bias
+		coef.SetUint64(hi & (1<<49 - 1))
+		coef.Lsh(coef, 64).Or(coef, new(big.Int).SetUint64(lo))
+		if coef.Cmp(decimal128Max) > 0 {
+			coef.SetInt64(0)
+		}
+	}
+	if hi>>63 != 0 {
+		coef.Neg(coef)
+	}
+	return Dec{unscaled: coef, scale: int32(-exp)}, nil
+}
@@ -0,0 +1,141 @@
+package variant
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestVariant_MarshalBSON(t *testing.T) {
+	doc := New(map[string]any{"hello": "world"})
+	data, err := doc.MarshalBSON()
+	assert(err == nil, err)
+	assert(bytes.Equal(data, []byte("\x16\x00\x00\x00\x02hello\x00\x06\x00\x00\x00world\x00\x00")), data)
+
+	tm := time.Date(2024, 5, 1, 10, 30, 0, 123000000, time.UTC)
+	m := NewMap()
+	m.Set("null", nil).
+		Set("bool", true).
+		Set("int8", int8(-8)).
+		Set("uint16", uint16(math.MaxUint16)).
+		Set("int32", int32(math.MinInt32)).
+		Set("int", 42).
+		Set("uint32", uint32(math.MaxUint32)).
+		Set("float", 1.5).
+		Set("string", "héllo").
+		Set("bytes", []byte{1, 2, 3}).
+		Set("time", tm).
+		Set("duration", time.Minute).
+		Set("decimal", NewDec(-1999, 2)).
+		Set("list", []any{1, "two", nil}).
+		Set("map", map[string]any{"k": int64(7)})
+	data, err = m.MarshalBSON()
+	assert(err == nil, err)
+
+	var got Variant
+	assert(got.UnmarshalBSON(data) == nil)
+	assert(len(got.Keys()) == len(m.Keys()), got.Keys())
+	want := map[string]Variant{
+		"null":     NewNull(Invalid),
+		"bool":     New(true),
+		"int8":     New(int32(-8)),
+		"uint16":   New(int32(math.MaxUint16)),
+		"int32":    New(int32(math.MinInt32)),
+		"int":      New(int64(42)),
+		"uint32":   New(int64(math.MaxUint32)),
+		"float":    New(1.5),
+		"string":   New("héllo"),
+		"bytes":    New([]byte{1, 2, 3}),
+		"time":     New(tm),
+		"duration": New(int64(time.Minute)),
+		"decimal":  New(NewDec(-1999, 2)),
+		"list":     NewList(int64(1), "two", nil),
+		"map":      New(map[string]any{"k": int64(7)}),
+	}
+	for i, key := range got.Keys() {
+		assert(key == m.Keys()[i], got.Keys())
+		assert(equal(got.Get(key), want[key]), key, got.Get(key), want[key])
+	}
+
+	_, err = New(1).MarshalBSON()
+	assert(errors.Is(err, ErrUnsupported), err)
+	bad := NewMap()
+	bad.Set("a\x00b", 1)
+	_, err = bad.MarshalBSON()
+	assert(err != nil)
+}
+
+func TestVariant_BSONDecimal128(t *testing.T) {
+	tests := []struct {
+		v    Variant
+		hex  string
+		want Dec
+	}{
+		// test vectors from the BSON corpus
+		{New(NewDec(1, 0)), "01000000000000000000000000004030", NewDec(1, 0)},
+		{New(NewDec(-1, 1)), "01000000000000000000000000003eb0", NewDec(-1, 1)},
+		{New(NewDec(0, 0)), "00000000000000000000000000004030", NewDec(0, 0)},
+		{New(uint64(math.MaxUint64)), "ffffffffffffffff0000000000004030", NewDecFromBigInt(new(big.Int).SetUint64(math.MaxUint64), 0)},
+		{New(new(big.Int).Lsh(big.NewInt(-1), 80)), "000000000000000000000100000040b0", NewDecFromBigInt(new(big.Int).Lsh(big.NewInt(-1), 80), 0)},
+	}
+	for _, tt := range tests {
+		m := NewMap()
+		m.Set("d", tt.v)
+		data, err := m.MarshalBSON()
+		assert(err == nil, tt.v, err)
+		// size, type and "d\x00" precede the value
+		assert(hex.EncodeToString(data[7:23]) == tt.hex, tt.v, hex.EncodeToString(data[7:23]))
+		var got Variant
+		assert(got.UnmarshalBSON(data) == nil)
+		d := got.Get("d")
+		assert(d.Type == Decimal && d.ToDecimal().Cmp(tt.want) == 0, d)
+	}
+
+	m := NewMap()
+	m.Set("d", NewDecFromBigInt(pow10(34), 0))
+	_, err := m.MarshalBSON()
+	assert(errors.Is(err, ErrOverflow), err)
+}
+
+func TestVariant_UnmarshalBSONErrors(t *testing.T) {
+	valid, _ := New(map[string]any{"a": "b"}).MarshalBSON()
+	objectID := []byte("\x16\x00\x00\x00\x07_id\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\x0a\x0b\x0c\x00")
+	for _, data := range [][]byte{
+		nil,
+		valid[:len(valid)-1],
+		append(valid, 0),
+		[]byte("\x05\x00\x00\x00\x01"), // missing terminator
+		[]byte("\x0c\x00\x00\x00\x02a\x00\x05\x00\x00\x00\x00"), // string longer than the document
+		objectID,
+	} {
+		var v Variant
+		assert(v.UnmarshalBSON(data) != nil, data)
+	}
+	var v Variant
+	assert(errors.Is(v.UnmarshalBSON(objectID), ErrUnsupported))
+}
+
+// nestedBSON returns a document nesting n documents in its element "a".
+func nestedBSON(n int) []byte {
+	var b []byte
+	for i := n; i > 0; i-- {
+		b = binary.LittleEndian.AppendUint32(b, uint32(5+8*i))
+		b = append(b, bsonDocument, 'a', 0)
+	}
+	b = append(b, 5, 0, 0, 0, 0)
+	return append(b, make([]byte, n)...)
+}
+
+func TestVariant_UnmarshalBSONDepth(t *testing.T) {
+	var v Variant
+	assert(v.UnmarshalBSON(nestedBSON(maxDepth-1)) == nil)
+	err := v.UnmarshalBSON(nestedBSON(maxDepth))
+	assert(errors.Is(err, errDepth), err)
+	err = v.UnmarshalBSON(nestedBSON(500000))
+	assert(errors.Is(err, errDepth), err)
+}
@@ -0,0 +1,169 @@
+package variant
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// Encoding converts binary data to and from text. *base64.Encoding implements
+// it, as does HexEncoding.
+type Encoding interface {
+	EncodeToString(src []byte) string
+	DecodeString(s string) ([]byte, error)
+}
+
+// BytesEncoding is the Encoding a Bytes variant is converted to a String with.
+var BytesEncoding Encoding = base64.StdEncoding
+
+// HexEncoding encodes binary data as lowercase hexadecimal digits.
+var HexEncoding Encoding = hexEncoding{}
+
+type hexEncoding struct{}
+
+func (hexEncoding) EncodeToString(src []byte) string {
+	return hex.EncodeToString(src)
+}
+
+func (hexEncoding) DecodeString(s string) ([]byte, error) {
+	return hex.DecodeString(s)
+}
+
+var _ IConvertStrategy[[]byte] = (*bytesConverter)(nil)
+
+// bytesConverter returns the bytes of a String or Bytes variant as they are,
+// and the text form of any other kind, e.g. "42" for an Int.
+type bytesConverter struct {
+	Converter[[]byte]
+	text stringConverter
+}
+
+func (c bytesConverter) FromString(v Variant) ([]byte, error) {
+	return bytes.Clone(v.Data), nil
+}
+
+func (c bytesConverter) FromBool(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromBool(v))
+}
+
+func (c bytesConverter) FromInt(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromInt(v))
+}
+
+func (c bytesConverter) FromInt8(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromInt8(v))
+}
+
+func (c bytesConverter) FromInt16(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromInt16(v))
+}
+
+func (c bytesConverter) FromInt32(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromInt32(v))
+}
+
+func (c bytesConverter) FromInt64(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromInt64(v))
+}
+
+func (c bytesConverter) FromUint(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromUint(v))
+}
+
+func (c bytesConverter) FromUint8(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromUint8(v))
+}
+
+func (c bytesConverter) FromUint16(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromUint16(v))
+}
+
+func (c bytesConverter) FromUint32(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromUint32(v))
+}
+
+func (c bytesConverter) FromUint64(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromUint64(v))
+}
+
+func (c bytesConverter) FromUintptr(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromUintptr(v))
+}
+
+func (c bytesConverter) FromFloat32(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromFloat32(v))
+}
+
+func (c bytesConverter) FromFloat64(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromFloat64(v))
+}
+
+func (c bytesConverter) FromComplex64(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromComplex64(v))
+}
+
+func (c bytesConverter) FromComplex128(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromComplex128(v))
+}
+
+func (c bytesConverter) FromTime(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromTime(v))
+}
+
+func (c bytesConverter) FromDuration(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromDuration(v))
+}
+
+func (c bytesConverter) FromBigInt(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromBigInt(v))
+}
+
+func (c bytesConverter) FromBigFloat(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromBigFloat(v))
+}
+
+func (c bytesConverter) FromDecimal(v Variant) ([]byte, error) {
+	return textBytes(c.text.FromDecimal(v))
+}
+
+func (c bytesConverter) FromBytes(v Variant) ([]byte, error) {
+	return bytes.Clone(v.Data), nil
+}
+
+func newBytesConverter() IConvertStrategy[[]byte] {
+	c := &bytesConverter{}
+	c.m = map[Kind]func(v Variant) ([]byte, error){
+		String:     c.FromString,
+		Bool:       c.FromBool,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Time:       c.FromTime,
+		Duration:   c.FromDuration,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
+		Bytes:      c.FromBytes,
+	}
+	return c
+}
+
+// textBytes returns the result of the string strategy as a byte slice.
+func textBytes(s string, err error) ([]byte, error) {
+	if err != nil {
+		return nil, retarget(err, Bytes)
+	}
+	return []byte(s), nil
+}
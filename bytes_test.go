@@ -0,0 +1,78 @@
+package variant
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVariant_Bytes(t *testing.T) {
+	blob := []byte{0xde, 0xad, 0xbe, 0xef}
+	v := New(blob)
+	assert(v.Type == Bytes)
+	assert(bytes.Equal(v.ToBytes(), blob))
+	blob[0] = 0x00
+	assert(v.ToBytes()[0] == 0xde, "New must copy the slice")
+	v.ToBytes()[1] = 0x00
+	assert(v.Data[1] == 0xad, "ToBytes must copy the payload")
+
+	assert(v.ToString() == "3q2+7w==")
+	assert(New(&blob).Type == Bytes)
+	assert(New(json.RawMessage(`{}`)).Type == Bytes)
+	assert(New([]byte(nil)).Type == Invalid)
+	assert(string(To[Raw](v)) == "\xde\xad\xbe\xef")
+
+	_, err := v.TryInt()
+	assert(errors.Is(err, ErrUnsupported), err)
+	_, err = v.TryTime()
+	assert(errors.Is(err, ErrUnsupported), err)
+
+	defer func(enc Encoding) { BytesEncoding = enc }(BytesEncoding)
+	BytesEncoding = HexEncoding
+	assert(v.ToString() == "deadbeef")
+}
+
+func TestVariant_ToBytes(t *testing.T) {
+	targets := []Pair[string]{
+		{"héllo", "héllo"},
+		{true, "true"},
+		{42, "42"},
+		{int16(-7), "-7"},
+		{uint64(1 << 40), "1099511627776"},
+		{1.5, "1.5"},
+		{time.Second, "1s"},
+		{NewDec(1999, 2), "19.99"},
+	}
+	for _, pair := range targets {
+		t.Run("ToBytes", func(t *testing.T) {
+			assert(string(New(pair.Key).ToBytes()) == pair.Val, pair.Key)
+		})
+	}
+	_, err := Nil.TryBytes()
+	assert(errors.Is(err, ErrUnsupported), err)
+}
+
+func TestVariant_DecodeBytes(t *testing.T) {
+	b, err := New("3q2+7w==").DecodeBytes(base64.StdEncoding)
+	assert(err == nil && bytes.Equal(b, []byte{0xde, 0xad, 0xbe, 0xef}), err)
+	b, err = New("DEADBEEF").DecodeBytes(HexEncoding)
+	assert(err == nil && bytes.Equal(b, []byte{0xde, 0xad, 0xbe, 0xef}), err)
+	_, err = New("xyz").DecodeBytes(HexEncoding)
+	assert(errors.Is(err, ErrSyntax), err)
+	b, _ = New([]byte{1, 2}).DecodeBytes(HexEncoding)
+	assert(bytes.Equal(b, []byte{1, 2}))
+}
+
+func TestVariant_BytesJSON(t *testing.T) {
+	data, err := json.Marshal(map[string]Variant{"blob": New([]byte("hi!")), "none": Nil})
+	assert(err == nil, err)
+	assert(string(data) == `{"blob":"aGkh","none":null}`, string(data))
+
+	var m map[string]Variant
+	assert(json.Unmarshal(data, &m) == nil)
+	b, err := m["blob"].DecodeBytes(base64.StdEncoding)
+	assert(err == nil && string(b) == "hi!", err)
+}
@@ -0,0 +1,635 @@
+package variant
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+// MarshalCBOR encodes each kind as the following CBOR (RFC 8949) items:
+//
+//	Invalid, typed nulls                 null
+//	Bool                                 false, true
+//	Int, Int8, ... Uint64, Uintptr       unsigned or negative integer
+//	Duration                             integer, in nanoseconds
+//	Float32, Float64                     single or double precision float
+//	String                               text string
+//	Bytes                                byte string
+//	Time                                 tag 1, epoch seconds, or tag 0, RFC 3339 text
+//	BigInt                               tag 2 or 3, bignum
+//	BigFloat                             tag 5, bigfloat
+//	Decimal                              tag 4, decimal fraction
+//	Complex64, Complex128                text string
+//	List                                 array
+//	Map                                  map of text string keys, keeping the order
+//
+// Integers are always written with the shortest argument. A Time with a
+// fractional second is written with tag 1 as a float, to the microsecond.
+//
+// UnmarshalCBOR decodes integers as Int64, or Uint64 and BigInt beyond its
+// range, half and single precision floats as Float32, double precision ones as
+// Float64, and tagged items back into the kinds above. It accepts
+// indefinite-length strings, arrays and maps, and decodes the content of
+// unknown tags as if they were untagged.
+
+const (
+	cborUint   = 0
+	cborNegint = 1
+	cborBytes  = 2
+	cborText   = 3
+	cborArray  = 4
+	cborMap    = 5
+	cborTag    = 6
+	cborSimple = 7
+
+	cborIndefinite = 31
+	cborBreak      = 0xff
+)
+
+// CBORMode controls how variants are encoded in CBOR.
+type CBORMode struct {
+	// Canonical selects the core deterministic encoding of RFC 8949 section
+	// 4.2: floats take the shortest form holding their value exactly, bignums
+	// that fit are written as plain integers, and map entries are sorted by
+	// their encoded keys. Equal variants then encode to the same bytes,
+	// whatever the order their map entries were added in.
+	Canonical bool
+
+	// TimeRFC3339 writes times with tag 0, as RFC 3339 text keeping the
+	// nanoseconds and the zone offset, rather than with tag 1.
+	TimeRFC3339 bool
+}
+
+// MarshalCBOR returns the CBOR encoding of v, see CBORMode to encode it
+// canonically.
+func (v Variant) MarshalCBOR() ([]byte, error) {
+	return CBORMode{}.Marshal(v)
+}
+
+// Marshal returns the CBOR encoding of v in mode m.
+func (m CBORMode) Marshal(v Variant) ([]byte, error) {
+	return m.append(nil, v)
+}
+
+func (m CBORMode) append(b []byte, v Variant) ([]byte, error) {
+	if v.null || v.Type == Invalid {
+		return append(b, 0xf6), nil
+	}
+	switch v.Type {
+	case Bool:
+		t, err := v.TryBool()
+		if err != nil {
+			return nil, err
+		}
+		if t {
+			return append(b, 0xf5), nil
+		}
+		return append(b, 0xf4), nil
+	case Int, Int8, Int16, Int32, Int64, Duration:
+		i, err := binaryInt(v)
+		if err != nil {
+			return nil, conversionError(v, Int64, err)
+		}
+		return appendCBORInt(b, i), nil
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		u, err := binaryUint(v)
+		if err != nil {
+			return nil, conversionError(v, Uint64, err)
+		}
+		return appendCBORHead(b, cborUint, u), nil
+	case Float32:
+		f, err := payloadFloat32(v)
+		if err != nil {
+			return nil, conversionError(v, Float32, err)
+		}
+		return m.appendFloat(b, float64(f), 32), nil
+	case Float64:
+		f, err := payloadFloat64(v)
+		if err != nil {
+			return nil, conversionError(v, Float64, err)
+		}
+		return m.appendFloat(b, f, 64), nil
+	case String:
+		b = appendCBORHead(b, cborText, uint64(len(v.Data)))
+		return append(b, v.Data...), nil
+	case Complex64, Complex128:
+		s, err := v.TryString()
+		if err != nil {
+			return nil, err
+		}
+		b = appendCBORHead(b, cborText, uint64(len(s)))
+		return append(b, s...), nil
+	case Bytes:
+		b = appendCBORHead(b, cborBytes, uint64(len(v.Data)))
+		return append(b, v.Data...), nil
+	case Time:
+		t, err := payloadTime(v)
+		if err != nil {
+			return nil, conversionError(v, Time, err)
+		}
+		return m.appendTime(b, t), nil
+	case BigInt:
+		x, err := payloadBigInt(v)
+		if err != nil {
+			return nil, conversionError(v, BigInt, err)
+		}
+		return appendCBORBigInt(b, x, m.Canonical), nil
+	case BigFloat:
+		f, err := payloadBigFloat(v)
+		if err != nil {
+			return nil, conversionError(v, BigFloat, err)
+		}
+		if f.IsInf() {
+			return m.appendFloat(b, math.Inf(f.Sign()), 64), nil
+		}
+		// f = mant × 2^(exp-prec) where mant is an integer of prec bits
+		mant := new(big.Float)
+		exp := f.MantExp(mant)
+		x, _ := mant.SetMantExp(mant, int(f.Prec())).Int(nil)
+		b = append(appendCBORHead(b, cborTag, 5), 0x82)
+		b = appendCBORInt(b, int64(exp)-int64(f.Prec()))
+		return appendCBORBigInt(b, x, true), nil
+	case Decimal:
+		d, err := payloadDec(v)
+		if err != nil {
+			return nil, conversionError(v, Decimal, err)
+		}
+		b = append(appendCBORHead(b, cborTag, 4), 0x82)
+		b = appendCBORInt(b, -int64(d.Scale()))
+		return appendCBORBigInt(b, d.Unscaled(), true), nil
+	case List:
+		b = appendCBORHead(b, cborArray, uint64(len(v.list)))
+		for _, elem := range v.list {
+			var err error
+			if b, err = m.append(b, elem); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case Map:
+		keys := v.Keys()
+		b = appendCBORHead(b, cborMap, uint64(len(keys)))
+		if m.Canonical {
+			return m.appendSorted(b, v)
+		}
+		for _, key := range keys {
+			var err error
+			b = appendCBORHead(b, cborText, uint64(len(key)))
+			b = append(b, key...)
+			if b, err = m.append(b, v.obj.m[key]); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, conversionError(v, Bytes, ErrUnsupported)
+}
+
+// appendSorted appends the entries of the Map variant v sorted by the
+// bytewise order of their encoded keys.
+func (m CBORMode) appendSorted(b []byte, v Variant) ([]byte, error) {
+	entries := make([][]byte, 0, v.Len())
+	for _, key := range v.Keys() {
+		e := appendCBORHead(nil, cborText, uint64(len(key)))
+		e = append(e, key...)
+		e, err := m.append(e, v.obj.m[key])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	// keys are unique, so comparing whole entries orders them by key
+	slices.SortFunc(entries, bytes.Compare)
+	for _, e := range entries {
+		b = append(b, e...)
+	}
+	return b, nil
+}
+
+// appendFloat appends f as a float of bitSize bits, or in canonical mode as
+// the shortest float holding it exactly.
+func (m CBORMode) appendFloat(b []byte, f float64, bitSize int) []byte {
+	if m.Canonical {
+		if h, ok := float16Bits(f); ok {
+			return binary.BigEndian.AppendUint16(append(b, 0xf9), h)
+		}
+		if float64(float32(f)) == f {
+			bitSize = 32
+		}
+	}
+	if bitSize == 32 {
+		return binary.BigEndian.AppendUint32(append(b, 0xfa), math.Float32bits(float32(f)))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xfb), math.Float64bits(f))
+}
+
+func (m CBORMode) appendTime(b []byte, t time.Time) []byte {
+	if m.TimeRFC3339 {
+		s := t.Format(time.RFC3339Nano)
+		b = appendCBORHead(appendCBORHead(b, cborTag, 0), cborText, uint64(len(s)))
+		return append(b, s...)
+	}
+	b = appendCBORHead(b, cborTag, 1)
+	if t.Nanosecond() == 0 {
+		return appendCBORInt(b, t.Unix())
+	}
+	return m.appendFloat(b, float64(t.UnixMicro())/1e6, 64)
+}
+
+// appendCBORHead appends the initial byte of an item of the major type major
+// followed by its argument n in the shortest form.
+func appendCBORHead(b []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major|27), n)
+}
+
+func appendCBORInt(b []byte, i int64) []byte {
+	if i < 0 {
+		return appendCBORHead(b, cborNegint, uint64(^i))
+	}
+	return appendCBORHead(b, cborUint, uint64(i))
+}
+
+// appendCBORBigInt appends x as a bignum, or as an integer when small is set
+// and x fits in one.
+func appendCBORBigInt(b []byte, x *big.Int, small bool) []byte {
+	tag, n := uint64(2), x
+	if x.Sign() < 0 {
+		// a negative bignum holds -1 - x
+		tag, n = 3, new(big.Int).Not(x)
+	}
+	if small && n.IsUint64() {
+		return appendCBORHead(b, byte(tag-2), n.Uint64())
+	}
+	mag := n.Bytes()
+	b = appendCBORHead(appendCBORHead(b, cborTag, tag), cborBytes, uint64(len(mag)))
+	return append(b, mag...)
+}
+
+// float16Bits returns the half precision bits of f, if f has one.
+func float16Bits(f float64) (uint16, bool) {
+	var sign uint16
+	if math.Signbit(f) {
+		sign = 0x8000
+	}
+	switch {
+	case math.IsNaN(f):
+		return 0x7e00, true
+	case math.IsInf(f, 0):
+		return sign | 0x7c00, true
+	case f == 0:
+		return sign, true
+	}
+	frac, exp := math.Frexp(math.Abs(f))
+	switch e := exp - 1; {
+	case e >= -14 && e <= 15:
+		m := (2*frac - 1) * 1024
+		if m == math.Trunc(m) {
+			return sign | uint16(e+15)<<10 | uint16(m), true
+		}
+	case e >= -24 && e < -14:
+		m := math.Abs(f) * (1 << 24)
+		if m == math.Trunc(m) {
+			return sign | uint16(m), true
+		}
+	}
+	return 0, false
+}
+
+// float16 returns the value of the half precision bits h.
+func float16(h uint16) float64 {
+	exp, mant := int(h>>10&0x1f), float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		f = math.Inf(1)
+		if mant != 0 {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
+
+// UnmarshalCBOR decodes the CBOR item in data, which must hold exactly one
+// item, into v. A null keeps the declared kind of v.
+func (v *Variant) UnmarshalCBOR(data []byte) error {
+	d := cborDecoder{data: data}
+	r, err := d.value()
+	if err != nil {
+		return err
+	}
+	if len(d.data) > 0 {
+		return errors.New("variant: trailing data after CBOR item")
+	}
+	if r.Type == Invalid {
+		r = NewNull(v.Type)
+	}
+	*v = r
+	return nil
+}
+
+type cborDecoder struct {
+	data  []byte
+	depth int // the nesting of the item being decoded, tags included
+}
+
+// head reads the initial byte of an item and its argument.
+func (d *cborDecoder) head() (major, info byte, n uint64, err error) {
+	if len(d.data) == 0 {
+		return 0, 0, 0, ErrTruncated
+	}
+	major, info = d.data[0]>>5, d.data[0]&0x1f
+	d.data = d.data[1:]
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info <= 27:
+		size := 1 << (info - 24)
+		if len(d.data) < size {
+			return 0, 0, 0, ErrTruncated
+		}
+		for _, c := range d.data[:size] {
+			n = n<<8 | uint64(c)
+		}
+		d.data = d.data[size:]
+		return major, info, n, nil
+	case info == cborIndefinite && major >= cborBytes && major <= cborMap || major == cborSimple:
+		return major, info, 0, nil
+	}
+	return 0, 0, 0, fmt.Errorf("variant: invalid CBOR initial byte 0x%02x", major<<5|info)
+}
+
+// more reports whether an indefinite-length item has more elements, consuming
+// its break when it has not.
+func (d *cborDecoder) more() (bool, error) {
+	if len(d.data) == 0 {
+		return false, ErrTruncated
+	}
+	if d.data[0] == cborBreak {
+		d.data = d.data[1:]
+		return false, nil
+	}
+	return true, nil
+}
+
+func (d *cborDecoder) value() (Variant, error) {
+	d.depth++
+	defer func() { d.depth-- }()
+	if d.depth > maxDepth {
+		return Nil, errDepth
+	}
+	major, info, n, err := d.head()
+	if err != nil {
+		return Nil, err
+	}
+	switch major {
+	case cborUint:
+		if n > math.MaxInt64 {
+			return New(n), nil
+		}
+		return New(int64(n)), nil
+	case cborNegint:
+		if n > math.MaxInt64 {
+			x := new(big.Int).SetUint64(n)
+			return New(x.Not(x)), nil
+		}
+		return New(^int64(n)), nil
+	case cborBytes, cborText:
+		b, err := d.str(major, info, n)
+		if err != nil {
+			return Nil, err
+		}
+		if major == cborBytes {
+			return New(b), nil
+		}
+		if !utf8.Valid(b) {
+			return Nil, fmt.Errorf("variant: invalid UTF-8 in CBOR text string: %w", ErrSyntax)
+		}
+		return Variant{Type: String, Data: b, layout: time.DateTime}, nil
+	case cborArray:
+		return d.array(info, n)
+	case cborMap:
+		return d.object(info, n)
+	case cborTag:
+		return d.tagged(n)
+	}
+
+	switch info {
+	case 20:
+		return New(false), nil
+	case 21:
+		return New(true), nil
+	case 22, 23: // null, undefined
+		return NewNull(Invalid), nil
+	case 25:
+		return New(float32(float16(uint16(n)))), nil
+	case 26:
+		return New(math.Float32frombits(uint32(n))), nil
+	case 27:
+		return New(math.Float64frombits(n)), nil
+	case cborIndefinite:
+		return Nil, errors.New("variant: unexpected CBOR break")
+	}
+	return Nil, fmt.Errorf("variant: CBOR simple value %d: %w", n, ErrUnsupported)
+}
+
+// str reads a byte or text string, concatenating the chunks of an
+// indefinite-length one.
+func (d *cborDecoder) str(major, info byte, n uint64) ([]byte, error) {
+	if info != cborIndefinite {
+		if n > uint64(len(d.data)) {
+			return nil, ErrTruncated
+		}
+		b := clone(d.data[:n])
+		d.data = d.data[n:]
+		return b, nil
+	}
+	b := []byte{}
+	for {
+		more, err := d.more()
+		if err != nil {
+			return nil, err
+		}
+		if !more {
+			return b, nil
+		}
+		m, info, n, err := d.head()
+		if err != nil {
+			return nil, err
+		}
+		if m != major || info == cborIndefinite {
+			return nil, errors.New("variant: invalid chunk in indefinite-length CBOR string")
+		}
+		if n > uint64(len(d.data)) {
+			return nil, ErrTruncated
+		}
+		b = append(b, d.data[:n]...)
+		d.data = d.data[n:]
+	}
+}
+
+func (d *cborDecoder) array(info byte, n uint64) (Variant, error) {
+	v := NewList()
+	if info != cborIndefinite {
+		if n > uint64(len(d.data)) {
+			// every element takes at least one byte
+			return Nil, ErrTruncated
+		}
+		v.list = make([]Variant, 0, min(n, 1024))
+	}
+	for i := uint64(0); info == cborIndefinite || i < n; i++ {
+		if info == cborIndefinite {
+			more, err := d.more()
+			if err != nil {
+				return Nil, err
+			}
+			if !more {
+				break
+			}
+		}
+		elem, err := d.value()
+		if err != nil {
+			return Nil, err
+		}
+		v.list = append(v.list, elem)
+	}
+	return v, nil
+}
+
+func (d *cborDecoder) object(info byte, n uint64) (Variant, error) {
+	v := NewMap()
+	if info != cborIndefinite && n > uint64(len(d.data)) {
+		return Nil, ErrTruncated
+	}
+	for i := uint64(0); info == cborIndefinite || i < n; i++ {
+		if info == cborIndefinite {
+			more, err := d.more()
+			if err != nil {
+				return Nil, err
+			}
+			if !more {
+				break
+			}
+		}
+		key, err := d.value()
+		if err != nil {
+			return Nil, err
+		}
+		k, err := key.TryString()
+		if err != nil {
+			return Nil, err
+		}
+		elem, err := d.value()
+		if err != nil {
+			return Nil, err
+		}
+		v.obj.set(k, elem)
+	}
+	return v, nil
+}
+
+// tagged decodes the content of an item of tag number tag.
+func (d *cborDecoder) tagged(tag uint64) (Variant, error) {
+	content, err := d.value()
+	if err != nil {
+		return Nil, err
+	}
+	switch tag {
+	case 0:
+		if content.Type != String {
+			break
+		}
+		t, err := time.Parse(time.RFC3339Nano, string(content.Data))
+		if err != nil {
+			return Nil, err
+		}
+		return New(t), nil
+	case 1:
+		return cborEpoch(content)
+	case 2, 3:
+		if content.Type != Bytes {
+			break
+		}
+		x := new(big.Int).SetBytes(content.Data)
+		if tag == 3 {
+			x.Not(x)
+		}
+		return New(x), nil
+	case 4, 5:
+		if content.Type != List || content.Len() != 2 || !isCBORInt(content.list[0]) || !isCBORInt(content.list[1]) {
+			break
+		}
+		exp, err := content.list[0].TryInt64()
+		if err != nil {
+			return Nil, err
+		}
+		mant := content.list[1].ToBigInt()
+		if tag == 4 {
+			if exp < -maxDecExponent || exp > maxDecExponent {
+				return Nil, &ConversionError{From: Int64, To: Decimal, Err: ErrOverflow}
+			}
+			return New(NewDecFromBigInt(mant, int32(-exp))), nil
+		}
+		if exp < math.MinInt32 || exp > math.MaxInt32 {
+			return Nil, &ConversionError{From: Int64, To: BigFloat, Err: ErrOverflow}
+		}
+		f := new(big.Float)
+		if mant.BitLen() > 0 {
+			f.SetPrec(uint(mant.BitLen()))
+		}
+		f.SetInt(mant)
+		return New(f.SetMantExp(f, int(exp))), nil
+	default:
+		// unknown tags, e.g. the self-described CBOR tag, are ignored
+		return content, nil
+	}
+	return Nil, fmt.Errorf("variant: invalid content for CBOR tag %d", tag)
+}
+
+// isCBORInt reports whether v was decoded from an integer or a bignum.
+func isCBORInt(v Variant) bool {
+	return v.Type == Int64 || v.Type == Uint64 || v.Type == BigInt
+}
+
+// cborEpoch returns the time an epoch-based date/time item holds.
+func cborEpoch(v Variant) (Variant, error) {
+	switch v.Type {
+	case Int64:
+		i, _ := payloadInt64(v)
+		return New(time.Unix(i, 0).UTC()), nil
+	case Float32, Float64:
+		f, err := v.TryFloat64()
+		if err != nil {
+			return Nil, err
+		}
+		us := math.Round(f * 1e6)
+		if math.IsNaN(us) || us < math.MinInt64 || us >= math.MaxInt64 {
+			return Nil, &ConversionError{From: v.Type, To: Time, Err: ErrOverflow}
+		}
+		return New(time.UnixMicro(int64(us)).UTC()), nil
+	}
+	return Nil, errors.New("variant: invalid content for CBOR tag 1")
+}
@@ -0,0 +1,192 @@
+package variant
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestVariant_MarshalCBOR(t *testing.T) {
+	bigInt, _ := new(big.Int).SetString("18446744073709551616", 10)
+	tests := []struct {
+		v    Variant
+		hex  string
+		want Variant
+	}{
+		// examples from RFC 8949 appendix A
+		{New(0), "00", New(int64(0))},
+		{New(uint8(24)), "1818", New(int64(24))},
+		{New(int16(1000)), "1903e8", New(int64(1000))},
+		{New(uint64(math.MaxUint64)), "1bffffffffffffffff", New(uint64(math.MaxUint64))},
+		{New(-1000), "3903e7", New(int64(-1000))},
+		{New(bigInt), "c249010000000000000000", New(bigInt)},
+		{New(new(big.Int).Not(bigInt)), "c349010000000000000000", New(new(big.Int).Not(bigInt))},
+		{New(float32(100000)), "fa47c35000", New(float32(100000))},
+		{New(1.1), "fb3ff199999999999a", New(1.1)},
+		{New(false), "f4", New(false)},
+		{Nil, "f6", NewNull(Invalid)},
+		{New(NewDec(27315, 2)), "c48221196ab3", New(NewDec(27315, 2))},
+		{New(time.Unix(1363896240, 0)), "c11a514b67b0", New(time.Unix(1363896240, 0).UTC())},
+		{New(time.Unix(1363896240, 500000000)), "c1fb41d452d9ec200000", New(time.Unix(1363896240, 500000000).UTC())},
+		{New([]byte{1, 2, 3, 4}), "4401020304", New([]byte{1, 2, 3, 4})},
+		{New("IETF"), "6449455446", New("IETF")},
+		{New("ü"), "62c3bc", New("ü")},
+		{New([]any{1, []any{2, 3}}), "8201820203", NewList(int64(1), NewList(int64(2), int64(3)))},
+		{New(map[string]any{"a": 1, "b": nil}), "a26161016162f6", New(map[string]any{"a": int64(1), "b": nil})},
+		{New(time.Second), "1a3b9aca00", New(int64(time.Second))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v.Type.String(), func(t *testing.T) {
+			data, err := tt.v.MarshalCBOR()
+			assert(err == nil, tt.v, err)
+			assert(hex.EncodeToString(data) == tt.hex, tt.v, hex.EncodeToString(data))
+			var got Variant
+			assert(got.UnmarshalCBOR(data) == nil, tt.hex)
+			assert(equal(got, tt.want), got, tt.want)
+		})
+	}
+
+	f, _, _ := big.ParseFloat("1.5", 10, 100, big.ToNearestEven)
+	data, err := New(f).MarshalCBOR()
+	assert(err == nil, err)
+	var got Variant
+	assert(got.UnmarshalCBOR(data) == nil)
+	assert(got.Type == BigFloat && got.ToBigFloat().Cmp(f) == 0 && got.ToBigFloat().Prec() == 100, got)
+
+	v := NewNull(Int)
+	assert(v.UnmarshalCBOR([]byte{0xf6}) == nil)
+	assert(v.Type == Int && v.IsNull(), v)
+}
+
+func TestCBORMode_Marshal(t *testing.T) {
+	canonical := CBORMode{Canonical: true}
+	tests := []struct {
+		v   Variant
+		hex string
+	}{
+		{New(0.0), "f90000"},
+		{New(math.Copysign(0, -1)), "f98000"},
+		{New(1.5), "f93e00"},
+		{New(65504.0), "f97bff"},
+		{New(5.960464477539063e-8), "f90001"},
+		{New(100000.0), "fa47c35000"},
+		{New(1.1), "fb3ff199999999999a"},
+		{New(math.Inf(-1)), "f9fc00"},
+		{New(math.NaN()), "f97e00"},
+		{New(big.NewInt(-5)), "24"},
+		{New(time.Unix(1363896240, 500000000)), "c1fb41d452d9ec200000"},
+	}
+	for _, tt := range tests {
+		data, err := canonical.Marshal(tt.v)
+		assert(err == nil, tt.v, err)
+		assert(hex.EncodeToString(data) == tt.hex, tt.v, hex.EncodeToString(data))
+	}
+
+	// map entries are sorted by their encoded keys, shorter keys first
+	a := NewMap()
+	a.Set("bb", 1).Set("a", 2).Set("c", NewList(1.5))
+	b := NewMap()
+	b.Set("c", NewList(1.5)).Set("bb", 1).Set("a", 2)
+	x, _ := canonical.Marshal(a)
+	y, _ := canonical.Marshal(b)
+	assert(bytes.Equal(x, y), x, y)
+	assert(hex.EncodeToString(x) == "a3616102616381f93e0062626201", hex.EncodeToString(x))
+
+	tm := time.Date(2013, 3, 21, 20, 4, 0, 5, time.FixedZone("", 3600))
+	data, err := CBORMode{TimeRFC3339: true}.Marshal(New(tm))
+	assert(err == nil, err)
+	assert(data[0] == 0xc0, data)
+	var got Variant
+	assert(got.UnmarshalCBOR(data) == nil)
+	assert(got.ToTime().Equal(tm), got)
+	_, offset := got.ToTime().Zone()
+	assert(offset == 3600, offset)
+}
+
+func TestVariant_UnmarshalCBOR(t *testing.T) {
+	tests := []struct {
+		hex  string
+		want Variant
+	}{
+		// indefinite-length items from RFC 8949 appendix A
+		{"5f42010243030405ff", New([]byte{1, 2, 3, 4, 5})},
+		{"7f657374726561646d696e67ff", New("streaming")},
+		{"9fff", NewList()},
+		{"9f018202039f0405ffff", NewList(int64(1), NewList(int64(2), int64(3)), NewList(int64(4), int64(5)))},
+		{"bf61610161629f0203ffff", New(map[string]any{"a": int64(1), "b": NewList(int64(2), int64(3))})},
+		{"f93c00", New(float32(1))},
+		{"f7", NewNull(Invalid)},
+		{"c074323031332d30332d32315432303a30343a30305a", New(time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC))},
+		{"d9d9f7820102", NewList(int64(1), int64(2))}, // self-described CBOR
+		{"a1016161", New(map[string]any{"1": "a"})},
+		{"c5822003", New(big.NewFloat(1.5))},
+	}
+	for _, tt := range tests {
+		data, _ := hex.DecodeString(tt.hex)
+		var got Variant
+		err := got.UnmarshalCBOR(data)
+		assert(err == nil, tt.hex, err)
+		assert(equal(got, tt.want), tt.hex, got, tt.want)
+	}
+
+	for _, s := range []string{
+		"",
+		"19",                 // short argument
+		"62c3",               // short text
+		"9f01",               // missing break
+		"5f6161ff",           // text chunk in a byte string
+		"62c328",             // invalid UTF-8
+		"ff",                 // unexpected break
+		"1c",                 // reserved additional information
+		"0101",               // trailing data
+		"c1617a",             // text epoch
+		"c48101",             // decimal fraction of the wrong size
+		"c4821a4000000001",   // decimal fraction with exponent 2^30
+		"c4823a3fffffff01",   // decimal fraction with exponent -2^30
+		"f0",                 // unassigned simple value
+		"9b0000000100000000", // array longer than the input
+	} {
+		data, _ := hex.DecodeString(s)
+		var v Variant
+		assert(v.UnmarshalCBOR(data) != nil, s)
+	}
+	var v Variant
+	err := v.UnmarshalCBOR([]byte{0x62, 0xc3, 0x28})
+	assert(errors.Is(err, ErrSyntax), err)
+	err = v.UnmarshalCBOR([]byte{0xc4, 0x82, 0x1a, 0x40, 0, 0, 0, 0x01})
+	assert(errors.Is(err, ErrOverflow), err)
+}
+
+func TestVariant_UnmarshalCBORDepth(t *testing.T) {
+	var v Variant
+	for _, item := range [][]byte{
+		{0x81},       // array of one item
+		{0xa1, 0x00}, // map of one entry
+		{0xc6},       // unknown tag 6
+		{0x9f},       // indefinite-length array
+	} {
+		// 2 MB of nested items
+		data := bytes.Repeat(item, 2<<20/len(item))
+		err := v.UnmarshalCBOR(data)
+		assert(errors.Is(err, errDepth), item, err)
+	}
+
+	data := append(bytes.Repeat([]byte{0x81}, maxDepth-1), 0x01)
+	assert(v.UnmarshalCBOR(data) == nil)
+	data = append(bytes.Repeat([]byte{0xc6}, maxDepth), 0x01)
+	err := v.UnmarshalCBOR(data)
+	assert(errors.Is(err, errDepth), err)
+}
+
+func TestVariant_UnmarshalCBORCount(t *testing.T) {
+	// 200 nested arrays each claiming as many items as the input has bytes
+	data := bytes.Repeat([]byte{0x9a, 0x00, 0x01, 0x90, 0x00}, 200)
+	data = append(data, make([]byte, 100<<10)...)
+	var v Variant
+	n := allocated(func() { v.UnmarshalCBOR(data) })
+	assert(n < 256<<20, n)
+}
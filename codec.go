@@ -2,7 +2,12 @@ package variant
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"strconv"
 )
 
 type JSONMarshaler interface {
@@ -23,20 +28,125 @@ type YAMLUnmarshaler interface {
 
 // MarshalJSON implements the JSONMarshaler interface.
 func (v Variant) MarshalJSON() ([]byte, error) {
+	if v.null {
+		return []byte("null"), nil
+	}
 	switch v.Type {
-	case String, Time:
+	case String, Time, Duration, Complex64, Complex128:
 		return json.Marshal(v.ToString())
 	case Bool:
 		return json.Marshal(v.ToBool())
 	case Int, Int8, Int16, Int32, Int64:
 		return json.Marshal(v.ToInt64())
-	case Uint, Uint8, Uint16, Uint32, Uint64:
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
 		return json.Marshal(v.ToUint64())
 	case Float32, Float64:
-		return json.Marshal(v.ToFloat64())
+		return marshalFloat(v.ToFloat64())
+	case BigInt, BigFloat:
+		return marshalBig(v)
+	case Decimal:
+		return v.ToDecimal().MarshalJSON()
+	case List:
+		if v.list == nil {
+			return []byte("[]"), nil
+		}
+		return json.Marshal(v.list)
+	case Map:
+		return v.marshalObject()
+	case Bytes:
+		return json.Marshal(base64.StdEncoding.EncodeToString(v.Data))
 	default:
-		return json.Marshal(v.Data)
+		return []byte("null"), nil
+	}
+}
+
+// marshalObject encodes the entries of a Map variant as a JSON object,
+// keeping their order.
+func (v Variant) marshalObject() ([]byte, error) {
+	buf := []byte{'{'}
+	for i, key := range v.Keys() {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		val, err := v.obj.m[key].MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, k...)
+		buf = append(buf, ':')
+		buf = append(buf, val...)
+	}
+	return append(buf, '}'), nil
+}
+
+// unmarshalTree decodes a JSON array or object into a List or Map variant,
+// keeping the order of the entries of objects. The whole tree is decoded in
+// one pass over the tokens of data.
+func (v *Variant) unmarshalTree(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	tree, err := decodeTree(dec, 0)
+	if err != nil {
+		return err
 	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("variant: trailing data after JSON value")
+	}
+	*v = tree
+	return nil
+}
+
+// decodeTree decodes the next value of dec, within depth arrays and objects.
+func decodeTree(dec *json.Decoder, depth int) (Variant, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return Nil, err
+	}
+	switch tok := tok.(type) {
+	case json.Delim:
+		if depth >= maxDepth {
+			return Nil, errDepth
+		}
+		v := NewList()
+		if tok == '{' {
+			v = NewMap()
+		}
+		for dec.More() {
+			var key string
+			if tok == '{' {
+				k, err := dec.Token()
+				if err != nil {
+					return Nil, err
+				}
+				key = k.(string)
+			}
+			elem, err := decodeTree(dec, depth+1)
+			if err != nil {
+				return Nil, err
+			}
+			if tok == '{' {
+				v.obj.set(key, elem)
+			} else {
+				v.list = append(v.list, elem)
+			}
+		}
+		// the closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return Nil, err
+		}
+		return v, nil
+	case string:
+		return New(tok), nil
+	case bool:
+		return New(tok), nil
+	case json.Number:
+		return newNumber([]byte(tok)), nil
+	}
+	return NewNull(Invalid), nil
 }
 
 // UnmarshalJSON implements the JSONUnmarshaler interface.
@@ -56,28 +166,114 @@ func (v *Variant) UnmarshalJSON(data []byte) error {
 	case bytes.Equal(data, []byte("false")):
 		*v = New(false)
 	case bytes.Equal(data, []byte("null")):
-		v.Type = Invalid
-		v.Data = nil
+		// keep the declared kind of the target, if any
+		*v = NewNull(v.Type)
+	case data[0] == '[' || data[0] == '{':
+		return v.unmarshalTree(data)
 	default:
-		v.Type = String
-		v.Data = data
+		*v = newNumber(data)
 	}
 	return nil
 }
 
+// PreserveNumberLiterals makes UnmarshalJSON decode a JSON number whose value
+// float64 cannot hold exactly, e.g. 0.10000000000000000001, into a BigFloat
+// variant rather than rounding it to a Float64.
+var PreserveNumberLiterals = false
+
+// newNumber returns a variant holding the JSON number lit. Integer literals
+// become Int64, Uint64 when they exceed the range of int64 and BigInt when
+// they exceed the range of uint64. The other literals become Float64, or
+// BigFloat when they exceed the range of float64.
+func newNumber(lit []byte) Variant {
+	s := string(lit)
+	if !bytes.ContainsAny(lit, ".eE") {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return New(i)
+		}
+		if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return New(u)
+		}
+		if b, ok := new(big.Int).SetString(s, 10); ok {
+			return New(b)
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || PreserveNumberLiterals && !isExactFloat(s, f) {
+		if b, err := parseBigFloat(s); err == nil {
+			return New(b)
+		}
+		return New(s)
+	}
+	return New(f)
+}
+
+// isExactFloat reports whether the shortest decimal form of f, which is how f
+// is encoded again, has the same value as the literal s.
+func isExactFloat(s string, f float64) bool {
+	r, ok := new(big.Rat).SetString(s)
+	g, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	return ok && r.Cmp(g) == 0
+}
+
+// marshalBig encodes a BigInt or BigFloat variant as a JSON number with all of
+// its digits.
+func marshalBig(v Variant) ([]byte, error) {
+	s, err := v.canonical().TryString()
+	if err != nil {
+		return nil, err
+	}
+	if v.Type == BigFloat && !json.Valid([]byte(s)) {
+		return nil, &json.UnsupportedValueError{Str: s}
+	}
+	return []byte(s), nil
+}
+
+// marshalFloat encodes f as a JSON number, with a fractional part even when f
+// is integral so that it is decoded as a Float64 again.
+func marshalFloat(f float64) ([]byte, error) {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.ContainsAny(b, ".eE") {
+		b = append(b, ".0"...)
+	}
+	return b, nil
+}
+
 // MarshalYAML implements the YAMLMarshaler interface.
 func (v Variant) MarshalYAML() (any, error) {
+	if v.null {
+		return nil, nil
+	}
 	switch v.Type {
-	case String, Time:
+	case String, Time, Duration, Complex64, Complex128:
 		return v.ToString(), nil
 	case Bool:
 		return v.ToBool(), nil
 	case Int, Int8, Int16, Int32, Int64:
 		return v.ToInt64(), nil
-	case Uint, Uint8, Uint16, Uint32, Uint64:
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
 		return v.ToUint64(), nil
 	case Float32, Float64:
 		return v.ToFloat64(), nil
+	case BigInt, BigFloat, Decimal:
+		return v.canonical().ToString(), nil
+	case List:
+		if v.list == nil {
+			return []Variant{}, nil
+		}
+		return v.list, nil
+	case Map:
+		if v.obj == nil {
+			return map[string]Variant{}, nil
+		}
+		// YAML encoders sort the keys of Go maps, keeping the order of the
+		// entries would need a node type of the YAML package
+		return v.obj.m, nil
+	case Bytes:
+		return v.ToBytes(), nil
 	case Invalid:
 		return nil, nil
 	default:
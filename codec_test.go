@@ -0,0 +1,108 @@
+package variant
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestVariant_UnmarshalJSONNumber(t *testing.T) {
+	targets := []Pair[Variant]{
+		{`36`, New(int64(36))},
+		{`-36`, New(int64(-36))},
+		{`0`, New(int64(0))},
+		{`9223372036854775807`, New(int64(math.MaxInt64))},
+		{`9223372036854775808`, New(uint64(math.MaxInt64 + 1))},
+		{`18446744073709551615`, New(uint64(math.MaxUint64))},
+		{`18446744073709551616`, New(new(big.Int).Lsh(big.NewInt(1), 64))},
+		{`-18446744073709551616`, New(new(big.Int).Lsh(big.NewInt(-1), 64))},
+		{`60.123`, New(60.123)},
+		{`2.0`, New(2.0)},
+		{`1e3`, New(1000.0)},
+		{`-1.5E-3`, New(-0.0015)},
+		{`1e400`, New(mustBigFloat("1e400"))},
+	}
+	for _, pair := range targets {
+		t.Run("UnmarshalJSON", func(t *testing.T) {
+			var v Variant
+			assert(json.Unmarshal([]byte(pair.Key.(string)), &v) == nil)
+			assert(v.Equal(pair.Val), pair.Key, v)
+
+			out, err := json.Marshal(v)
+			assert(err == nil)
+			var u Variant
+			assert(json.Unmarshal(out, &u) == nil)
+			assert(u.Equal(v), pair.Key, string(out))
+		})
+	}
+}
+
+func TestVariant_PreserveNumberLiterals(t *testing.T) {
+	defer func(preserve bool) { PreserveNumberLiterals = preserve }(PreserveNumberLiterals)
+	PreserveNumberLiterals = true
+
+	targets := []Pair[Variant]{
+		{`36`, New(int64(36))},
+		{`0.5`, New(0.5)},
+		{`1.25`, New(1.25)},
+		{`18446744073709551616`, New(new(big.Int).Lsh(big.NewInt(1), 64))},
+		{`0.10000000000000000001`, New(mustBigFloat("0.10000000000000000001"))},
+		{`0.1`, New(0.1)},
+	}
+	for _, pair := range targets {
+		t.Run("PreserveNumberLiterals", func(t *testing.T) {
+			var v Variant
+			assert(json.Unmarshal([]byte(pair.Key.(string)), &v) == nil)
+			assert(v.Equal(pair.Val), pair.Key, v)
+
+			out, err := json.Marshal(v)
+			assert(err == nil && string(out) == pair.Key, string(out))
+		})
+	}
+}
+
+func mustBigFloat(s string) *big.Float {
+	f, err := parseBigFloat(s)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func TestVariant_JSONRoundTrip(t *testing.T) {
+	data := []byte(`{"age":36,"weight":60.5,"ratio":1.0,"big":18446744073709551615,"name":"36","ok":true,"nothing":null}`)
+	var v Variant
+	assert(json.Unmarshal(data, &v) == nil)
+	assert(v.Get("age").Equal(int64(36)))
+	assert(v.Get("age").Equal(36) && v.Get("age").Equal(uint8(36)) && v.Get("age").Equal(36.0))
+	assert(!v.Get("age").Equal(37) && !v.Get("age").Equal("36"))
+	assert(v.Get("big").Equal(New(big.NewInt(0).SetUint64(math.MaxUint64))))
+	assert(v.Equal(map[string]any{"age": 36, "weight": 60.5, "ratio": 1, "big": uint64(math.MaxUint64),
+		"name": "36", "ok": true, "nothing": nil}))
+	assert(v.Get("age").ToInt() == 36)
+	assert(v.Get("ratio").Type == Float64)
+	assert(v.Get("big").Type == Uint64)
+	assert(v.Get("name").Type == String)
+
+	out, err := json.Marshal(v)
+	assert(err == nil && string(out) == string(data), string(out))
+}
+
+func TestVariant_UnmarshalJSONDepth(t *testing.T) {
+	nested := func(open, close string, n int) []byte {
+		return []byte(strings.Repeat(open, n) + "1" + strings.Repeat(close, n))
+	}
+	// each level used to be scanned again, taking seconds
+	var v Variant
+	assert(v.UnmarshalJSON(nested(`{"a":`, "}", maxDepth)) == nil)
+	assert(v.Get("a").Get("a").Type == Map)
+	assert(v.UnmarshalJSON(nested("[", "]", maxDepth)) == nil)
+	assert(v.Index(0).Index(0).Type == List)
+
+	// newer versions of encoding/json bound the depth themselves
+	assert(v.UnmarshalJSON(nested("[", "]", maxDepth+1)) != nil)
+	assert(v.UnmarshalJSON([]byte(`[1] 2`)) != nil)
+	assert(v.UnmarshalJSON([]byte(`{"a" 1}`)) != nil)
+}
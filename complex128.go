@@ -0,0 +1,231 @@
+package variant
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"unsafe"
+)
+
+var _ IConvertStrategy[complex128] = (*complex128Converter)(nil)
+
+type complex128Converter struct {
+	Converter[complex128]
+}
+
+func (c complex128Converter) FromString(v Variant) (complex128, error) {
+	s := *(*string)(unsafe.Pointer(&v.Data))
+	z, err := strconv.ParseComplex(s, 128)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, conversionError(v, Complex128, ErrOverflow)
+		}
+		return 0, conversionError(v, Complex128, ErrSyntax)
+	}
+	return complex128(z), nil
+}
+
+func (c complex128Converter) FromBool(v Variant) (complex128, error) {
+	if len(v.Data) == 0 || v.Data[0] == 0x00 {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func (c complex128Converter) FromInt(v Variant) (complex128, error) {
+	i, err := payloadInt(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(i), 0), nil
+}
+
+func (c complex128Converter) FromInt8(v Variant) (complex128, error) {
+	i, err := payloadInt8(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(i), 0), nil
+}
+
+func (c complex128Converter) FromInt16(v Variant) (complex128, error) {
+	i, err := payloadInt16(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(i), 0), nil
+}
+
+func (c complex128Converter) FromInt32(v Variant) (complex128, error) {
+	i, err := payloadInt32(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(i), 0), nil
+}
+
+func (c complex128Converter) FromInt64(v Variant) (complex128, error) {
+	i, err := payloadInt64(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(i), 0), nil
+}
+
+func (c complex128Converter) FromUint(v Variant) (complex128, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(i), 0), nil
+}
+
+func (c complex128Converter) FromUint8(v Variant) (complex128, error) {
+	i, err := payloadUint8(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(i), 0), nil
+}
+
+func (c complex128Converter) FromUint16(v Variant) (complex128, error) {
+	i, err := payloadUint16(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(i), 0), nil
+}
+
+func (c complex128Converter) FromUint32(v Variant) (complex128, error) {
+	i, err := payloadUint32(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(i), 0), nil
+}
+
+func (c complex128Converter) FromUint64(v Variant) (complex128, error) {
+	i, err := payloadUint64(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(i), 0), nil
+}
+
+func (c complex128Converter) FromUintptr(v Variant) (complex128, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(i), 0), nil
+}
+
+func (c complex128Converter) FromFloat32(v Variant) (complex128, error) {
+	f, err := payloadFloat32(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(f), 0), nil
+}
+
+func (c complex128Converter) FromFloat64(v Variant) (complex128, error) {
+	f, err := payloadFloat64(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(f), 0), nil
+}
+
+func (c complex128Converter) FromComplex64(v Variant) (complex128, error) {
+	z, err := payloadComplex64(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex128(z), nil
+}
+
+func (c complex128Converter) FromComplex128(v Variant) (complex128, error) {
+	z, err := payloadComplex128(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex128(z), nil
+}
+
+func (c complex128Converter) FromTime(v Variant) (complex128, error) {
+	t, err := payloadTime(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(epochFloat(v, t), 0), nil
+}
+
+func (c complex128Converter) FromDuration(v Variant) (complex128, error) {
+	d, err := payloadDuration(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(float64(d)/float64(v.durationUnit()), 0), nil
+}
+
+func (c complex128Converter) FromBigInt(v Variant) (complex128, error) {
+	b, err := payloadBigInt(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	f, _ := new(big.Float).SetInt(b).Float64()
+	if math.IsInf(float64(f), 0) {
+		return 0, conversionError(v, Complex128, ErrOverflow)
+	}
+	return complex(f, 0), nil
+}
+
+func (c complex128Converter) FromBigFloat(v Variant) (complex128, error) {
+	x, err := payloadBigFloat(v)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	f, _ := x.Float64()
+	if math.IsInf(float64(f), 0) && !x.IsInf() {
+		return 0, conversionError(v, Complex128, ErrOverflow)
+	}
+	return complex(f, 0), nil
+}
+
+func (c complex128Converter) FromDecimal(v Variant) (complex128, error) {
+	f, err := payloadDecFloat(v, 64)
+	if err != nil {
+		return 0, conversionError(v, Complex128, err)
+	}
+	return complex(f, 0), nil
+}
+
+func newComplex128Converter() IConvertStrategy[complex128] {
+	c := &complex128Converter{}
+	c.m = map[Kind]func(v Variant) (complex128, error){
+		String:     c.FromString,
+		Bool:       c.FromBool,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Time:       c.FromTime,
+		Duration:   c.FromDuration,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
+	}
+	return c
+}
@@ -0,0 +1,74 @@
+package variant
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestVariant_ToComplex128(t *testing.T) {
+	tt := time.Now()
+	targets := []Pair[complex128]{
+		{true, 1},
+		{false, 0},
+		{"1+2i", complex(1, 2)},
+		{"(1.5-2i)", complex(1.5, -2)},
+		{"3i", complex(0, 3)},
+		{"abc", 0},
+		{math.MinInt8, -128},
+		{uint32(math.MaxUint32), math.MaxUint32},
+		{uintptr(86), 86},
+		{-100.86, -100.86},
+		{complex64(complex(1, -2)), complex(1, -2)},
+		{complex(1.5, 2.5), complex(1.5, 2.5)},
+		{tt, complex(float64(tt.UnixNano()), 0)},
+	}
+	for _, pair := range targets {
+		t.Run("ToComplex128", func(t *testing.T) {
+			v := New(pair.Key)
+			assert(v.ToComplex128() == pair.Val, pair.Key)
+		})
+	}
+	assert(New("1+2i").ToComplex64() == complex64(complex(1, 2)))
+	assert(New(1.5).ToComplex64() == complex64(complex(1.5, 0)))
+}
+
+func TestVariant_Complex(t *testing.T) {
+	v := New(complex(1.5, -2))
+	assert(v.Type == Complex128)
+	assert(v.ToString() == "1.5-2i", v.ToString())
+	assert(v.ToFloat64() == 1.5)
+	assert(v.ToInt() == 1)
+	assert(v.ToBool())
+	assert(New(complex64(complex(0.1, 2))).ToString() == "0.1+2i")
+	assert(New(complex64(complex(0.1, 2))).ToFloat64() == 0.1)
+	assert(New(complex(-1, 0)).ToUint() == 0)
+
+	c := complex(3, 4)
+	assert(New(&c).Equal(c))
+	_, err := New("1+").TryComplex128()
+	assert(errors.Is(err, ErrSyntax), err)
+
+	b, err := json.Marshal(v)
+	assert(err == nil && string(b) == `"1.5-2i"`, string(b))
+	var d Variant
+	assert(json.Unmarshal(b, &d) == nil)
+	assert(d.ToComplex128() == complex(1.5, -2))
+}
+
+func TestVariant_Uintptr(t *testing.T) {
+	p := uintptr(10086)
+	v := New(&p)
+	assert(v.Type == Uintptr)
+	assert(v.ToUintptr() == 10086)
+	assert(v.ToString() == "10086")
+	assert(v.ToInt64() == 10086)
+	assert(v.ToFloat64() == 10086)
+	assert(New("42").ToUintptr() == 42)
+	assert(New(-1).ToUintptr() == 0)
+
+	b, err := json.Marshal(v)
+	assert(err == nil && string(b) == "10086", string(b))
+}
@@ -1,59 +1,249 @@
 package variant
 
-import "time"
+import (
+	"math"
+	"math/big"
+	"time"
+	"unsafe"
+)
 
 type IStrategy[T any] interface {
-	Get(k Kind) func(v Variant) T
+	Get(k Kind) func(v Variant) (T, error)
 }
 
 type IConvertStrategy[T any] interface {
 	IStrategy[T]
-	FromString(v Variant) T
-	FromBool(v Variant) T
-	FromInt(v Variant) T
-	FromInt8(v Variant) T
-	FromInt16(v Variant) T
-	FromInt32(v Variant) T
-	FromInt64(v Variant) T
-	FromUint(v Variant) T
-	FromUint8(v Variant) T
-	FromUint16(v Variant) T
-	FromUint32(v Variant) T
-	FromUint64(v Variant) T
-	FromFloat32(v Variant) T
-	FromFloat64(v Variant) T
-	FromTime(v Variant) T
+	FromString(v Variant) (T, error)
+	FromBool(v Variant) (T, error)
+	FromInt(v Variant) (T, error)
+	FromInt8(v Variant) (T, error)
+	FromInt16(v Variant) (T, error)
+	FromInt32(v Variant) (T, error)
+	FromInt64(v Variant) (T, error)
+	FromUint(v Variant) (T, error)
+	FromUint8(v Variant) (T, error)
+	FromUint16(v Variant) (T, error)
+	FromUint32(v Variant) (T, error)
+	FromUint64(v Variant) (T, error)
+	FromUintptr(v Variant) (T, error)
+	FromFloat32(v Variant) (T, error)
+	FromFloat64(v Variant) (T, error)
+	FromComplex64(v Variant) (T, error)
+	FromComplex128(v Variant) (T, error)
+	FromTime(v Variant) (T, error)
+	FromDuration(v Variant) (T, error)
+	FromBigInt(v Variant) (T, error)
+	FromBigFloat(v Variant) (T, error)
+	FromDecimal(v Variant) (T, error)
 	// add more methods below for other types as needed
 }
 
 var Strategies = strategies{
-	string:  newStringConverter(),
-	int:     newIntConverter(),
-	int64:   newInt64Converter(),
-	uint:    newUintConverter(),
-	uint64:  newUint64Converter(),
-	float32: newFloat32Converter(),
-	float64: newFloat64Converter(),
-	time:    newTimeConverter(),
+	bool:       newBoolConverter(),
+	string:     newStringConverter(),
+	int:        newIntConverter(),
+	int64:      newInt64Converter(),
+	uint:       newUintConverter(),
+	uint64:     newUint64Converter(),
+	float32:    newFloat32Converter(),
+	float64:    newFloat64Converter(),
+	complex64:  newComplex64Converter(),
+	complex128: newComplex128Converter(),
+	time:       newTimeConverter(),
+	duration:   newDurationConverter(),
+	bigInt:     newBigIntConverter(),
+	bigFloat:   newBigFloatConverter(),
+	decimal:    newDecimalConverter(),
+	bytes:      newBytesConverter(),
 	// add more strategies for other types as needed
 }
 
 type strategies struct {
-	string  IConvertStrategy[string]
-	int     IConvertStrategy[int]
-	int64   IConvertStrategy[int64]
-	uint    IConvertStrategy[uint]
-	uint64  IConvertStrategy[uint64]
-	float32 IConvertStrategy[float32]
-	float64 IConvertStrategy[float64]
-	time    IConvertStrategy[time.Time]
+	bool       IStrategy[bool]
+	string     IConvertStrategy[string]
+	int        IConvertStrategy[int]
+	int64      IConvertStrategy[int64]
+	uint       IConvertStrategy[uint]
+	uint64     IConvertStrategy[uint64]
+	float32    IConvertStrategy[float32]
+	float64    IConvertStrategy[float64]
+	complex64  IConvertStrategy[complex64]
+	complex128 IConvertStrategy[complex128]
+	time       IConvertStrategy[time.Time]
+	duration   IConvertStrategy[time.Duration]
+	bigInt     IConvertStrategy[*big.Int]
+	bigFloat   IConvertStrategy[*big.Float]
+	decimal    IConvertStrategy[Dec]
+	bytes      IConvertStrategy[[]byte]
 	// add more strategies for other types as needed
 }
 
 type Converter[T any] struct {
-	m map[Kind]func(v Variant) T
+	m map[Kind]func(v Variant) (T, error)
 }
 
-func (c Converter[T]) Get(k Kind) func(v Variant) T {
+func (c Converter[T]) Get(k Kind) func(v Variant) (T, error) {
 	return c.m[k]
 }
+
+// newBoolConverter returns the conversions to bool of every kind but Invalid:
+// a variant is true when its payload has a byte that is not zero.
+func newBoolConverter() Converter[bool] {
+	c := Converter[bool]{m: make(map[Kind]func(v Variant) (bool, error), len(kindNames))}
+	for k := range kindNames {
+		if Kind(k) != Invalid {
+			c.m[Kind(k)] = payloadBool
+		}
+	}
+	return c
+}
+
+// convert applies the conversion registered for T and the kind of v, falling
+// back to the function of s handling that kind. to is the kind of T, reported
+// when the conversion fails.
+func convert[T any](s IStrategy[T], to Kind, v Variant) (T, error) {
+	if v.null {
+		var zero T
+		return zero, conversionError(v, to, ErrNull)
+	}
+	if fn := lookup[T](v.Type); fn != nil {
+		return fn(v)
+	}
+	if s != nil {
+		if fn := s.Get(v.Type); fn != nil {
+			return fn(v)
+		}
+	}
+	var zero T
+	return zero, conversionError(v, to, ErrUnsupported)
+}
+
+// narrowInt converts v to int64 and checks that the result fits in T, unless a
+// conversion to T itself has been registered.
+func narrowInt[T int8 | int16 | int32](v Variant, to Kind) (T, error) {
+	if v.null {
+		return 0, conversionError(v, to, ErrNull)
+	}
+	if fn := lookup[T](v.Type); fn != nil {
+		return fn(v)
+	}
+	i, err := v.TryInt64()
+	if err != nil {
+		return 0, retarget(err, to)
+	}
+	if int64(T(i)) != i {
+		return saturate[T](v, to, i < 0)
+	}
+	return T(i), nil
+}
+
+// narrowUint converts v to uint64 and checks that the result fits in T, unless
+// a conversion to T itself has been registered.
+func narrowUint[T uint8 | uint16 | uint32 | uintptr](v Variant, to Kind) (T, error) {
+	if v.null {
+		return 0, conversionError(v, to, ErrNull)
+	}
+	if fn := lookup[T](v.Type); fn != nil {
+		return fn(v)
+	}
+	i, err := v.TryUint64()
+	if err != nil {
+		return 0, retarget(err, to)
+	}
+	if uint64(T(i)) != i {
+		return saturate[T](v, to, false)
+	}
+	return T(i), nil
+}
+
+type integer interface {
+	int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | uintptr
+}
+
+// intRange returns the smallest and the largest value of T.
+func intRange[T integer]() (lo, hi T) {
+	bits := unsafe.Sizeof(hi) * 8
+	if ^T(0) < 0 {
+		hi = T(uint64(math.MaxUint64) >> (65 - bits))
+		return -hi - 1, hi
+	}
+	return 0, ^T(0)
+}
+
+// saturate reports that v is out of the range of T, below it if neg. When
+// the policy of v saturates, the nearest bound of T is returned instead.
+func saturate[T integer](v Variant, to Kind, neg bool) (T, error) {
+	if !v.options().Saturate {
+		return 0, conversionError(v, to, ErrOverflow)
+	}
+	lo, hi := intRange[T]()
+	if neg {
+		return lo, nil
+	}
+	return hi, nil
+}
+
+// floatInt rounds f, the value of v, to T with the rounding mode of v. NaN is
+// reported as ErrOverflow, values out of range too unless v saturates, and
+// values with a fractional part as ErrInexact if v is strict.
+func floatInt[T integer](v Variant, to Kind, f float64) (T, error) {
+	if math.IsNaN(f) {
+		return 0, conversionError(v, to, ErrOverflow)
+	}
+	r := roundFloat(v, f)
+	if r != f && v.options().Strict {
+		return 0, conversionError(v, to, ErrInexact)
+	}
+	lo, hi := intRange[T]()
+	switch {
+	case r < float64(lo):
+		return saturate[T](v, to, true)
+	case r >= 2*float64(hi/2+1):
+		return saturate[T](v, to, false)
+	}
+	return T(r), nil
+}
+
+// intFloat converts i, the value of v, to the float type F, reporting an
+// integer F cannot hold exactly as ErrInexact if v is strict.
+func intFloat[F float32 | float64, T integer](v Variant, to Kind, i T) (F, error) {
+	f := F(i)
+	if v.options().Strict {
+		_, hi := intRange[T]()
+		if float64(f) >= 2*float64(hi/2+1) || T(f) != i {
+			return 0, conversionError(v, to, ErrInexact)
+		}
+	}
+	return f, nil
+}
+
+// narrowFloat checks f, the value of v rounded to the float type F, which is
+// out of the range of F if overflow and differs from the value unless exact.
+// Out of range, f is clamped to the largest F if v saturates, and reported as
+// ErrOverflow if v is strict. A value that changed is ErrInexact if v is
+// strict.
+func narrowFloat[F float32 | float64](v Variant, to Kind, f F, overflow, exact bool) (F, error) {
+	o := v.options()
+	switch {
+	case overflow && o.Saturate:
+		limit := math.MaxFloat64
+		if to == Float32 {
+			limit = math.MaxFloat32
+		}
+		return F(math.Copysign(limit, float64(f))), nil
+	case overflow && o.Strict:
+		return 0, conversionError(v, to, ErrOverflow)
+	case !exact && o.Strict:
+		return 0, conversionError(v, to, ErrInexact)
+	}
+	return f, nil
+}
+
+// complexInt is floatInt for the real part of z, an imaginary part other than
+// zero is reported as ErrInexact if v is strict.
+func complexInt[T integer](v Variant, to Kind, z complex128) (T, error) {
+	if imag(z) != 0 && v.options().Strict {
+		return 0, conversionError(v, to, ErrInexact)
+	}
+	return floatInt[T](v, to, real(z))
+}
@@ -0,0 +1,566 @@
+package variant
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+var _ IConvertStrategy[Dec] = (*decimalConverter)(nil)
+
+type decimalConverter struct {
+	Converter[Dec]
+}
+
+func (c decimalConverter) FromString(v Variant) (Dec, error) {
+	b, err := v.numeral()
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	d, err := ParseDec(*(*string)(unsafe.Pointer(&b)))
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return d, nil
+}
+
+func (c decimalConverter) FromBool(v Variant) (Dec, error) {
+	if len(v.Data) == 0 || v.Data[0] == 0x00 {
+		return Dec{}, nil
+	}
+	return NewDec(1, 0), nil
+}
+
+func (c decimalConverter) FromInt(v Variant) (Dec, error) {
+	i, err := payloadInt(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return NewDec(i, 0), nil
+}
+
+func (c decimalConverter) FromInt8(v Variant) (Dec, error) {
+	i, err := payloadInt8(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return NewDec(int64(i), 0), nil
+}
+
+func (c decimalConverter) FromInt16(v Variant) (Dec, error) {
+	i, err := payloadInt16(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return NewDec(int64(i), 0), nil
+}
+
+func (c decimalConverter) FromInt32(v Variant) (Dec, error) {
+	i, err := payloadInt32(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return NewDec(int64(i), 0), nil
+}
+
+func (c decimalConverter) FromInt64(v Variant) (Dec, error) {
+	i, err := payloadInt64(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return NewDec(i, 0), nil
+}
+
+func (c decimalConverter) FromUint(v Variant) (Dec, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return Dec{unscaled: new(big.Int).SetUint64(i)}, nil
+}
+
+func (c decimalConverter) FromUint8(v Variant) (Dec, error) {
+	i, err := payloadUint8(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return NewDec(int64(i), 0), nil
+}
+
+func (c decimalConverter) FromUint16(v Variant) (Dec, error) {
+	i, err := payloadUint16(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return NewDec(int64(i), 0), nil
+}
+
+func (c decimalConverter) FromUint32(v Variant) (Dec, error) {
+	i, err := payloadUint32(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return NewDec(int64(i), 0), nil
+}
+
+func (c decimalConverter) FromUint64(v Variant) (Dec, error) {
+	i, err := payloadUint64(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return Dec{unscaled: new(big.Int).SetUint64(i)}, nil
+}
+
+func (c decimalConverter) FromUintptr(v Variant) (Dec, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return Dec{unscaled: new(big.Int).SetUint64(i)}, nil
+}
+
+func (c decimalConverter) FromFloat32(v Variant) (Dec, error) {
+	f, err := payloadFloat32(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	d, err := decOfFloat(float64(f), 32)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return d, nil
+}
+
+func (c decimalConverter) FromFloat64(v Variant) (Dec, error) {
+	f, err := payloadFloat64(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	d, err := decOfFloat(f, 64)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return d, nil
+}
+
+func (c decimalConverter) FromComplex64(v Variant) (Dec, error) {
+	z, err := payloadComplex64(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	d, err := decOfFloat(float64(real(z)), 32)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return d, nil
+}
+
+func (c decimalConverter) FromComplex128(v Variant) (Dec, error) {
+	z, err := payloadComplex128(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	d, err := decOfFloat(real(z), 64)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return d, nil
+}
+
+func (c decimalConverter) FromTime(v Variant) (Dec, error) {
+	t, err := payloadTime(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return epochDec(v, t), nil
+}
+
+func (c decimalConverter) FromDuration(v Variant) (Dec, error) {
+	d, err := payloadDuration(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	unit := NewDec(int64(v.durationUnit()), 0)
+	return NewDec(int64(d), 0).Quo(unit, durationScale, RoundHalfEven).reduce(), nil
+}
+
+func (c decimalConverter) FromBigInt(v Variant) (Dec, error) {
+	b, err := payloadBigInt(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return Dec{unscaled: b}, nil
+}
+
+func (c decimalConverter) FromBigFloat(v Variant) (Dec, error) {
+	f, err := payloadBigFloat(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	if f.IsInf() {
+		return Dec{}, conversionError(v, Decimal, ErrOverflow)
+	}
+	d, err := ParseDec(f.Text('f', -1))
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return d, nil
+}
+
+func (c decimalConverter) FromDecimal(v Variant) (Dec, error) {
+	d, err := payloadDec(v)
+	if err != nil {
+		return Dec{}, conversionError(v, Decimal, err)
+	}
+	return d, nil
+}
+
+func newDecimalConverter() IConvertStrategy[Dec] {
+	c := &decimalConverter{}
+	c.m = map[Kind]func(v Variant) (Dec, error){
+		String:     c.FromString,
+		Bool:       c.FromBool,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Time:       c.FromTime,
+		Duration:   c.FromDuration,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
+	}
+	return c
+}
+
+// RoundingMode determines how a value is rounded when digits are discarded.
+type RoundingMode uint8
+
+const (
+	RoundTruncate RoundingMode = iota // toward zero
+	RoundHalfEven                     // to nearest, ties to even
+	RoundHalfUp                       // to nearest, ties away from zero
+	RoundFloor                        // toward negative infinity
+	RoundCeiling                      // toward positive infinity
+)
+
+func (m RoundingMode) String() string {
+	if uint(m) < uint(len(roundingModeNames)) {
+		return roundingModeNames[m]
+	}
+	return "RoundingMode" + strconv.Itoa(int(m))
+}
+
+var roundingModeNames = []string{
+	RoundTruncate: "RoundTruncate",
+	RoundHalfEven: "RoundHalfEven",
+	RoundHalfUp:   "RoundHalfUp",
+	RoundFloor:    "RoundFloor",
+	RoundCeiling:  "RoundCeiling",
+}
+
+// durationScale is the number of fractional digits kept when a duration is
+// converted to a decimal number of DurationUnit.
+const durationScale = 18
+
+// maxDecExponent bounds the exponent accepted by ParseDec, so that a short
+// literal such as "1e999999999" cannot allocate an enormous number.
+const maxDecExponent = 1 << 16
+
+// Dec is an exact decimal number, the value of which is unscaled × 10^-scale:
+// the scale is the number of digits after the decimal point. The zero value is
+// 0, Dec values are immutable and safe to copy.
+type Dec struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDec returns the decimal unscaled × 10^-scale, e.g. NewDec(1999, 2) is
+// 19.99.
+func NewDec(unscaled int64, scale int32) Dec {
+	return Dec{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// NewDecFromBigInt returns the decimal unscaled × 10^-scale.
+func NewDecFromBigInt(unscaled *big.Int, scale int32) Dec {
+	return Dec{unscaled: new(big.Int).Set(unscaled), scale: scale}
+}
+
+// ParseDec parses a decimal number such as "19.99", "-0.5" or "1.25e3". The
+// scale of the result is the number of digits after the decimal point, less
+// the exponent, and is never negative. Errors are ErrSyntax or ErrOverflow for
+// exponents beyond ±65536.
+func ParseDec(s string) (Dec, error) {
+	mantissa, exponent, hasExponent := s, "", false
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa, exponent, hasExponent = s[:i], s[i+1:], true
+	}
+	neg := false
+	if mantissa != "" && (mantissa[0] == '-' || mantissa[0] == '+') {
+		neg = mantissa[0] == '-'
+		mantissa = mantissa[1:]
+	}
+	whole, frac, _ := strings.Cut(mantissa, ".")
+	digits := whole + frac
+	if digits == "" || strings.ContainsFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) {
+		return Dec{}, ErrSyntax
+	}
+	scale := int64(len(frac))
+	if hasExponent {
+		exp, err := strconv.ParseInt(exponent, 10, 64)
+		if err != nil {
+			if strings.Trim(exponent, "+-0123456789") != "" || strings.Trim(exponent, "+-") == "" {
+				return Dec{}, ErrSyntax
+			}
+			return Dec{}, ErrOverflow
+		}
+		if exp > maxDecExponent || exp < -maxDecExponent {
+			return Dec{}, ErrOverflow
+		}
+		scale -= exp
+	}
+	unscaled, _ := new(big.Int).SetString(digits, 10)
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	if scale > maxDecExponent {
+		return Dec{}, ErrOverflow
+	}
+	if neg {
+		unscaled.Neg(unscaled)
+	}
+	return Dec{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+func (d Dec) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// Unscaled returns the unscaled value of d.
+func (d Dec) Unscaled() *big.Int {
+	return new(big.Int).Set(d.int())
+}
+
+// Scale returns the number of digits after the decimal point of d.
+func (d Dec) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d Dec) Sign() int {
+	return d.int().Sign()
+}
+
+// Cmp compares d and e and returns -1, 0 or +1, regardless of their scales.
+func (d Dec) Cmp(e Dec) int {
+	x, y := align(d, e)
+	return x.Cmp(y)
+}
+
+// Neg returns -d.
+func (d Dec) Neg() Dec {
+	return Dec{unscaled: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Add returns d + e with the larger of their scales.
+func (d Dec) Add(e Dec) Dec {
+	x, y := align(d, e)
+	return Dec{unscaled: x.Add(x, y), scale: max(d.scale, e.scale)}
+}
+
+// Sub returns d - e with the larger of their scales.
+func (d Dec) Sub(e Dec) Dec {
+	x, y := align(d, e)
+	return Dec{unscaled: x.Sub(x, y), scale: max(d.scale, e.scale)}
+}
+
+// Mul returns d × e with the sum of their scales.
+func (d Dec) Mul(e Dec) Dec {
+	return Dec{unscaled: new(big.Int).Mul(d.int(), e.int()), scale: d.scale + e.scale}
+}
+
+// Quo returns d / e with the given scale, rounded according to mode. Quo
+// panics if e is zero.
+func (d Dec) Quo(e Dec, scale int32, mode RoundingMode) Dec {
+	// d / e = (du × 10^(scale - ds + es) / eu) × 10^-scale
+	x := new(big.Int).Set(d.int())
+	y := new(big.Int).Set(e.int())
+	if shift := int64(scale) - int64(d.scale) + int64(e.scale); shift >= 0 {
+		x.Mul(x, pow10(shift))
+	} else {
+		y.Mul(y, pow10(-shift))
+	}
+	return Dec{unscaled: roundQuo(x, y, mode), scale: scale}
+}
+
+// Round returns d with the given scale, rounded according to mode when digits
+// are discarded.
+func (d Dec) Round(scale int32, mode RoundingMode) Dec {
+	if scale >= d.scale {
+		x := new(big.Int).Mul(d.int(), pow10(int64(scale)-int64(d.scale)))
+		return Dec{unscaled: x, scale: scale}
+	}
+	return Dec{unscaled: roundQuo(d.int(), pow10(int64(d.scale)-int64(scale)), mode), scale: scale}
+}
+
+// reduce returns d without the trailing zeros of its fractional part.
+func (d Dec) reduce() Dec {
+	x, r := new(big.Int), new(big.Int)
+	ten := big.NewInt(10)
+	for d.scale > 0 {
+		x.QuoRem(d.int(), ten, r)
+		if r.Sign() != 0 {
+			break
+		}
+		d = Dec{unscaled: new(big.Int).Set(x), scale: d.scale - 1}
+	}
+	return d
+}
+
+// integer returns d truncated toward zero.
+func (d Dec) integer() *big.Int {
+	return d.Round(0, RoundTruncate).int()
+}
+
+// String formats d with exactly Scale digits after the decimal point.
+func (d Dec) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	var sb strings.Builder
+	if d.Sign() < 0 {
+		sb.WriteByte('-')
+	}
+	switch {
+	case d.scale <= 0:
+		sb.WriteString(digits)
+		if d.Sign() != 0 {
+			sb.WriteString(strings.Repeat("0", int(-d.scale)))
+		}
+	case len(digits) <= int(d.scale):
+		sb.WriteString("0.")
+		sb.WriteString(strings.Repeat("0", int(d.scale)-len(digits)))
+		sb.WriteString(digits)
+	default:
+		point := len(digits) - int(d.scale)
+		sb.WriteString(digits[:point])
+		sb.WriteByte('.')
+		sb.WriteString(digits[point:])
+	}
+	return sb.String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d Dec) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (d *Dec) UnmarshalText(text []byte) error {
+	dec, err := ParseDec(string(text))
+	if err != nil {
+		return err
+	}
+	*d = dec
+	return nil
+}
+
+// MarshalJSON implements the JSONMarshaler interface, encoding d as a JSON
+// number with all of its digits.
+func (d Dec) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON implements the JSONUnmarshaler interface, accepting a JSON
+// number or a string holding one.
+func (d *Dec) UnmarshalJSON(data []byte) error {
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	return d.UnmarshalText(data)
+}
+
+// decOfFloat returns the shortest decimal representation of f that rounds back
+// to f at the given bit size, e.g. 0.1 rather than 0.1000000000000000055511.
+// NaN and infinities are reported as ErrOverflow.
+func decOfFloat(f float64, bitSize int) (Dec, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Dec{}, ErrOverflow
+	}
+	return ParseDec(strconv.FormatFloat(f, 'f', -1, bitSize))
+}
+
+// align returns the unscaled values of d and e brought to the same scale.
+func align(d, e Dec) (*big.Int, *big.Int) {
+	x := new(big.Int).Set(d.int())
+	y := new(big.Int).Set(e.int())
+	if d.scale < e.scale {
+		x.Mul(x, pow10(int64(e.scale)-int64(d.scale)))
+	} else if e.scale < d.scale {
+		y.Mul(y, pow10(int64(d.scale)-int64(e.scale)))
+	}
+	return x, y
+}
+
+// pow10 returns 10^n.
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+// roundQuo returns x / y rounded according to mode.
+func roundQuo(x, y *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	neg := (x.Sign() < 0) != (y.Sign() < 0)
+	half := new(big.Int).Abs(r)
+	half.Lsh(half, 1).Sub(half, new(big.Int).Abs(y)) // 2|r| - |y|, compared to 0
+	if roundAway(mode, neg, half.Sign(), q.Bit(0) == 1) {
+		if neg {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// roundAway reports whether mode rounds a value away from zero when the
+// fraction it discards is not zero. half compares that fraction to one half,
+// neg is the sign of the value and odd whether its truncation is odd.
+func roundAway(mode RoundingMode, neg bool, half int, odd bool) bool {
+	switch mode {
+	case RoundHalfEven:
+		return half > 0 || half == 0 && odd
+	case RoundHalfUp:
+		return half >= 0
+	case RoundFloor:
+		return neg
+	case RoundCeiling:
+		return !neg
+	}
+	return false
+}
+
+// payload encodes d as the Data of a Decimal variant.
+func (d Dec) payload() []byte {
+	b, _ := d.int().GobEncode()
+	return append(binary.BigEndian.AppendUint32(nil, uint32(d.scale)), b...)
+}
@@ -0,0 +1,157 @@
+package variant
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestParseDec(t *testing.T) {
+	targets := []Pair[string]{
+		{"19.99", "19.99"},
+		{"-0.005", "-0.005"},
+		{"+1.50", "1.50"},
+		{"1.25e3", "1250"},
+		{"1.25E-3", "0.00125"},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"0", "0"},
+	}
+	for _, pair := range targets {
+		t.Run("ParseDec", func(t *testing.T) {
+			d, err := ParseDec(pair.Key.(string))
+			assert(err == nil, pair.Key, err)
+			assert(d.String() == pair.Val, pair.Key, d.String())
+		})
+	}
+	for _, s := range []string{"", "-", ".", "1..2", "1e", "e5", "0x10", "1_000", "1.5x"} {
+		_, err := ParseDec(s)
+		assert(errors.Is(err, ErrSyntax), s, err)
+	}
+	_, err := ParseDec("1e99999999999")
+	assert(errors.Is(err, ErrOverflow), err)
+}
+
+func TestDec_Round(t *testing.T) {
+	type round struct {
+		in   string
+		mode RoundingMode
+		out  string
+	}
+	for _, r := range []round{
+		{"2.345", RoundTruncate, "2.34"},
+		{"2.345", RoundHalfEven, "2.34"},
+		{"2.355", RoundHalfEven, "2.36"},
+		{"2.345", RoundHalfUp, "2.35"},
+		{"-2.345", RoundHalfUp, "-2.35"},
+		{"-2.341", RoundFloor, "-2.35"},
+		{"2.341", RoundFloor, "2.34"},
+		{"2.341", RoundCeiling, "2.35"},
+		{"-2.349", RoundCeiling, "-2.34"},
+		{"-2.349", RoundTruncate, "-2.34"},
+		{"2.3", RoundHalfEven, "2.30"},
+	} {
+		d, _ := ParseDec(r.in)
+		got := d.Round(2, r.mode).String()
+		assert(got == r.out, r.in, r.mode, got)
+	}
+	assert(NewDec(1250, 0).Round(-2, RoundHalfEven).String() == "1200")
+}
+
+func TestDec_Arithmetic(t *testing.T) {
+	a, _ := ParseDec("0.1")
+	b, _ := ParseDec("0.20")
+	assert(a.Add(b).String() == "0.30")
+	assert(a.Sub(b).String() == "-0.10")
+	assert(a.Mul(b).String() == "0.020")
+	assert(a.Add(b).Cmp(NewDec(3, 1)) == 0)
+	assert(NewDec(1, 0).Quo(NewDec(3, 0), 4, RoundHalfEven).String() == "0.3333")
+	assert(NewDec(2, 0).Quo(NewDec(-3, 0), 2, RoundHalfUp).String() == "-0.67")
+	assert(NewDec(2, 0).Quo(NewDec(-3, 0), 2, RoundFloor).String() == "-0.67")
+	assert(NewDec(2, 0).Quo(NewDec(-3, 0), 2, RoundCeiling).String() == "-0.66")
+	assert(Dec{}.String() == "0" && Dec{}.Sign() == 0)
+	assert(NewDec(-5, 1).Neg().String() == "0.5")
+}
+
+func TestVariant_Decimal(t *testing.T) {
+	d, _ := ParseDec("12345678901234567890.05")
+	v := New(d)
+	assert(v.Type == Decimal)
+	assert(v.ToString() == "12345678901234567890.05")
+	assert(v.ToDecimal().Cmp(d) == 0)
+	assert(v.ToBigInt().String() == "12345678901234567890")
+	assert(v.ToUint64() == 12345678901234567890)
+	assert(v.ToFloat64() == 1.2345678901234567e19)
+	assert(To[Dec](v).String() == d.String())
+	assert(New(&d).Equal(d))
+	assert(New((*Dec)(nil)).Equal(NewNull(Decimal)))
+
+	_, err := v.TryInt64()
+	assert(errors.Is(err, ErrOverflow), err)
+	_, err = New(NewDec(-100, 2)).TryUint()
+	assert(errors.Is(err, ErrOverflow), err)
+	_, err = New(NewDec(1, -1<<30)).TryString()
+	assert(errors.Is(err, ErrOverflow), err)
+	_, err = New(NewDec(1, 1<<30)).TryInt()
+	assert(errors.Is(err, ErrOverflow), err)
+
+	small := New(NewDec(-1999, 2))
+	assert(small.ToInt() == -19)
+	assert(small.ToInt8() == -19)
+	assert(small.ToFloat32() == -19.99)
+	assert(small.ToBigFloat().Text('g', -1) == "-19.99")
+
+	defer func(unit time.Duration) { DurationUnit = unit }(DurationUnit)
+	DurationUnit = time.Second
+	assert(New(NewDec(15, 1)).ToDuration() == 1500*time.Millisecond)
+	assert(New(1500*time.Millisecond).ToDecimal().String() == "1.5")
+	assert(New(90*time.Second).ToDecimal().String() == "90")
+	DurationUnit = time.Minute
+	assert(New(90*time.Second).ToDecimal().String() == "1.5")
+	assert(New(time.Second).ToDecimal().String() == "0.016666666666666667")
+
+	b, err := json.Marshal(map[string]Variant{"price": small})
+	assert(err == nil, err)
+	assert(string(b) == `{"price":-19.99}`, string(b))
+}
+
+func TestVariant_ToDecimal(t *testing.T) {
+	targets := []Pair[string]{
+		{"19.990", "19.990"},
+		{true, "1"},
+		{int8(-8), "-8"},
+		{uint64(math.MaxUint64), "18446744073709551615"},
+		{0.1, "0.1"},
+		{float32(0.1), "0.1"},
+		{1e21, "1000000000000000000000"},
+		{complex(2.5, 1), "2.5"},
+		{1500 * time.Millisecond, "1500000000"},
+		{big.NewInt(-7), "-7"},
+		{mustBigFloat("0.10000000000000000001"), "0.10000000000000000001"},
+	}
+	for _, pair := range targets {
+		t.Run("ToDecimal", func(t *testing.T) {
+			got := New(pair.Key).ToDecimal().String()
+			assert(got == pair.Val, pair.Key, got)
+		})
+	}
+	_, err := New("abc").TryDecimal()
+	assert(errors.Is(err, ErrSyntax), err)
+	_, err = New(math.NaN()).TryDecimal()
+	assert(errors.Is(err, ErrOverflow), err)
+
+	// JSON numbers keep their literal digits
+	var v Variant
+	assert(json.Unmarshal([]byte("19.99"), &v) == nil)
+	assert(v.ToDecimal().String() == "19.99")
+
+	var price struct{ Price Dec }
+	assert(json.Unmarshal([]byte(`{"Price": 0.30000000000000000001}`), &price) == nil)
+	assert(price.Price.String() == "0.30000000000000000001")
+	b, _ := json.Marshal(price)
+	assert(string(b) == `{"Price":0.30000000000000000001}`, string(b))
+}
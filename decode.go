@@ -0,0 +1,307 @@
+package variant
+
+import (
+	"encoding"
+	"errors"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	variantType  = reflect.TypeFor[Variant]()
+	decType      = reflect.TypeFor[Dec]()
+	durationType = reflect.TypeFor[time.Duration]()
+	bigIntType   = reflect.TypeFor[*big.Int]()
+	bigFloatType = reflect.TypeFor[*big.Float]()
+)
+
+// FieldError records why the value at Path could not be decoded, e.g.
+// "items[2].price".
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError lists every value Decode could not decode. The other values are
+// decoded regardless.
+type DecodeError struct {
+	Errors []*FieldError
+}
+
+func (e *DecodeError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *DecodeError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Decode stores src, a Variant or any value New accepts, in the value dst
+// points to. Map variants decode into structs and maps, List variants into
+// slices and arrays, pointers are allocated as needed, strings decode into
+// types implementing encoding.TextUnmarshaler and the other values are
+// converted like To does. Fields of type Variant receive the variant as
+// is, so do interface fields.
+//
+// Struct fields are matched by name, case-insensitively, or by the name given
+// in a tag of the form
+//
+//	`variant:"name,layout=2006-01-02,default=42"`
+//
+// where layout is the time layout strings are parsed with and default is the
+// value decoded when the field is missing or null. A field tagged "-" is
+// skipped. Default must be the last option, it may contain commas.
+//
+// Decode reports a *DecodeError listing the path of each value it could not
+// decode.
+func Decode(src any, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("variant: Decode requires a non-nil pointer")
+	}
+	d := decoder{}
+	d.decode(New(src), rv.Elem(), "")
+	if len(d.errs) > 0 {
+		return &DecodeError{Errors: d.errs}
+	}
+	return nil
+}
+
+type decoder struct {
+	errs []*FieldError
+}
+
+func (d *decoder) fail(path string, err error) {
+	d.errs = append(d.errs, &FieldError{Path: path, Err: err})
+}
+
+func (d *decoder) decode(v Variant, dst reflect.Value, path string) {
+	t := dst.Type()
+	if t == variantType {
+		dst.Set(reflect.ValueOf(v))
+		return
+	}
+	if v.IsNull() {
+		// like encoding/json, null clears pointers, maps, slices and
+		// interfaces and leaves any other value alone
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+			dst.SetZero()
+		}
+		return
+	}
+	if fn := lookupType(t, v.Type); fn != nil {
+		x, err := fn(v)
+		if err != nil {
+			d.fail(path, err)
+			return
+		}
+		dst.Set(reflect.ValueOf(x))
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if t == bigIntType || t == bigFloatType {
+			break
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(t.Elem()))
+		}
+		d.decode(v, dst.Elem(), path)
+		return
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			dst.Set(reflect.ValueOf(v))
+			return
+		}
+	case reflect.Struct:
+		if v.Type == Map && t != decType && !timeType.ConvertibleTo(t) {
+			d.decodeStruct(v, dst, path)
+			return
+		}
+	case reflect.Slice:
+		if v.Type == List && t.Elem().Kind() != reflect.Uint8 {
+			s := reflect.MakeSlice(t, v.Len(), v.Len())
+			for i := range v.Len() {
+				d.decode(v.Index(i), s.Index(i), index(path, i))
+			}
+			dst.Set(s)
+			return
+		}
+	case reflect.Array:
+		if v.Type == Bytes && t.Elem().Kind() == reflect.Uint8 {
+			reflect.Copy(dst, reflect.ValueOf(v.Data))
+			return
+		}
+		if v.Type == List {
+			for i := range min(v.Len(), dst.Len()) {
+				d.decode(v.Index(i), dst.Index(i), index(path, i))
+			}
+			return
+		}
+	case reflect.Map:
+		if v.Type == Map {
+			d.decodeMap(v, dst, path)
+			return
+		}
+	}
+	p := dst.Addr().Interface()
+	if u, ok := p.(encoding.TextUnmarshaler); ok && v.Type == String && t != timeType && t != decType {
+		// e.g. net.IP, whereas time.Time and Dec keep their strategies
+		if err := u.UnmarshalText(v.Data); err != nil {
+			d.fail(path, err)
+		}
+		return
+	}
+	if err := assign(v, p); err != nil {
+		d.fail(path, err)
+	}
+}
+
+func (d *decoder) decodeStruct(v Variant, dst reflect.Value, path string) {
+	t := dst.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup("variant")
+		if tag == "-" || !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		opts := parseTag(tag)
+		if f.Anonymous && !tagged && indirect(f.Type).Kind() == reflect.Struct {
+			// the fields of embedded structs are promoted, as in encoding/json
+			fv := dst.Field(i)
+			if f.Type.Kind() == reflect.Pointer {
+				if !fv.CanSet() {
+					continue
+				}
+				if fv.IsNil() {
+					fv.Set(reflect.New(f.Type.Elem()))
+				}
+				fv = fv.Elem()
+			}
+			d.decodeStruct(v, fv, path)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := opts.name
+		if name == "" {
+			name = f.Name
+		}
+		key, elem := field(v, name)
+		if elem.IsNull() && opts.hasDefault {
+			elem = New(opts.def)
+		}
+		if opts.layout != "" {
+			elem.SetLayout(opts.layout)
+		}
+		d.decode(elem, dst.Field(i), member(path, key))
+	}
+}
+
+func (d *decoder) decodeMap(v Variant, dst reflect.Value, path string) {
+	t := dst.Type()
+	if dst.IsNil() {
+		dst.Set(reflect.MakeMapWithSize(t, v.Len()))
+	}
+	for _, key := range v.Keys() {
+		k := reflect.New(t.Key()).Elem()
+		if t.Key().Kind() == reflect.String {
+			k.SetString(key)
+		} else if err := assign(New(key), k.Addr().Interface()); err != nil {
+			d.fail(member(path, key), err)
+			continue
+		}
+		elem := reflect.New(t.Elem()).Elem()
+		if old := dst.MapIndex(k); old.IsValid() {
+			elem.Set(old)
+		}
+		d.decode(v.Get(key), elem, member(path, key))
+		dst.SetMapIndex(k, elem)
+	}
+}
+
+// field returns the key and the entry of the Map variant v named name, falling
+// back to a case-insensitive match.
+func field(v Variant, name string) (string, Variant) {
+	keys := v.Keys()
+	if slices.Contains(keys, name) {
+		return name, v.Get(name)
+	}
+	for _, key := range keys {
+		if strings.EqualFold(key, name) {
+			return key, v.Get(key)
+		}
+	}
+	return name, Nil
+}
+
+type tagOptions struct {
+	name       string
+	layout     string
+	def        string
+	hasDefault bool
+	omitEmpty  bool
+}
+
+// parseTag parses a variant struct tag, see Decode and Encode.
+func parseTag(tag string) tagOptions {
+	name, rest, _ := strings.Cut(tag, ",")
+	opts := tagOptions{name: name}
+	for rest != "" {
+		if def, ok := strings.CutPrefix(rest, "default="); ok {
+			opts.def, opts.hasDefault = def, true
+			break
+		}
+		var opt string
+		opt, rest, _ = strings.Cut(rest, ",")
+		if layout, ok := strings.CutPrefix(opt, "layout="); ok {
+			opts.layout = layout
+		}
+		opts.omitEmpty = opts.omitEmpty || opt == "omitempty"
+	}
+	return opts
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+func member(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func index(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
@@ -0,0 +1,143 @@
+package variant
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+type decodeAddress struct {
+	Street string
+	Zip    int `variant:"zip_code"`
+}
+
+type decodeBase struct {
+	ID int64
+}
+
+type decodeUser struct {
+	decodeBase
+	Name      string
+	Age       uint8
+	Score     *float64
+	Joined    time.Time     `variant:"joined,layout=2006-01-02"`
+	Timeout   time.Duration `variant:"timeout"`
+	Role      string        `variant:"role,default=guest"`
+	Tags      []string
+	Address   decodeAddress
+	Previous  []*decodeAddress
+	Limits    map[string]int
+	Price     Dec
+	Extra     Variant
+	Raw       any
+	Secret    string `variant:"-"`
+	unexposed int
+}
+
+func TestDecode(t *testing.T) {
+	var src Variant
+	err := json.Unmarshal([]byte(`{
+		"id": 7,
+		"NAME": "ann",
+		"age": "42",
+		"score": 9.5,
+		"joined": "2024-05-01",
+		"timeout": "PT1M",
+		"tags": ["a", 2, true],
+		"address": {"street": "Main", "zip_code": "12345"},
+		"previous": [{"street": "Old"}, null],
+		"limits": {"cpu": 2, "mem": "512"},
+		"price": 19.99,
+		"extra": [1, 2],
+		"raw": "x",
+		"secret": "s3cr3t"
+	}`), &src)
+	assert(err == nil, err)
+
+	var u decodeUser
+	assert(Decode(src, &u) == nil)
+	assert(u.ID == 7 && u.Name == "ann" && u.Age == 42)
+	assert(u.Score != nil && *u.Score == 9.5)
+	assert(u.Joined.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)), u.Joined)
+	assert(u.Timeout == time.Minute)
+	assert(u.Role == "guest")
+	assert(strings.Join(u.Tags, ",") == "a,2,true")
+	assert(u.Address == decodeAddress{"Main", 12345})
+	assert(len(u.Previous) == 2 && u.Previous[0].Street == "Old" && u.Previous[1] == nil)
+	assert(len(u.Limits) == 2 && u.Limits["mem"] == 512)
+	assert(u.Price.String() == "19.99")
+	assert(u.Extra.Type == List && u.Extra.Len() == 2)
+	assert(u.Raw.(Variant).ToString() == "x")
+	assert(u.Secret == "")
+
+	var m map[string]int
+	assert(Decode(map[string]any{"a": 1, "b": "2"}, &m) == nil)
+	assert(m["a"] == 1 && m["b"] == 2)
+
+	var keys map[int]bool
+	assert(Decode(map[string]any{"1": true}, &keys) == nil && keys[1])
+
+	var arr [2]int
+	assert(Decode([]any{1, 2, 3}, &arr) == nil && arr == [2]int{1, 2})
+
+	var num struct{ N *big.Int }
+	assert(Decode(map[string]any{"n": "123456789012345678901234567890"}, &num) == nil)
+	assert(num.N.String() == "123456789012345678901234567890")
+
+	var n int
+	assert(Decode(New("17"), &n) == nil && n == 17)
+	assert(Decode(New("17"), n) != nil)
+}
+
+type decodeCelsius struct{ deg float64 }
+
+func TestDecode_Registered(t *testing.T) {
+	RegisterFunc(String, func(v Variant) (decodeCelsius, error) {
+		f, err := New(strings.TrimSuffix(v.ToString(), "C")).TryFloat64()
+		return decodeCelsius{f}, err
+	})
+	defer Unregister[decodeCelsius]()
+
+	var reading struct {
+		Temp  decodeCelsius
+		Temps []decodeCelsius
+	}
+	err := Decode(map[string]any{"temp": "21.5C", "temps": []any{"1C", "xC"}}, &reading)
+	assert(reading.Temp.deg == 21.5)
+	assert(len(reading.Temps) == 2 && reading.Temps[0].deg == 1)
+	var fe *FieldError
+	assert(errors.As(err, &fe) && fe.Path == "temps[1]", err)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	src := New(map[string]any{
+		"age":     "old",
+		"tags":    []any{"a", "b"},
+		"address": map[string]any{"zip_code": "abc"},
+		"limits":  map[string]any{"cpu": 1e300},
+		"name":    "bob",
+	})
+	var u struct {
+		Name    string
+		Age     int
+		Tags    []int
+		Address decodeAddress
+		Limits  map[string]int8
+	}
+	err := Decode(src, &u)
+	var de *DecodeError
+	assert(errors.As(err, &de), err)
+
+	var paths []string
+	for _, fe := range de.Errors {
+		paths = append(paths, fe.Path)
+	}
+	got := strings.Join(paths, " ")
+	assert(got == "age tags[0] tags[1] address.zip_code limits.cpu", got)
+	assert(errors.Is(err, ErrSyntax) && errors.Is(err, ErrOverflow))
+	assert(u.Name == "bob", "valid fields are decoded regardless")
+	assert(strings.HasPrefix(err.Error(), "age: variant: cannot convert string to int: invalid syntax; "), err)
+}
@@ -0,0 +1,445 @@
+package variant
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// DurationUnit is the unit of the numbers converted to and from durations,
+// e.g. with DurationUnit set to time.Second New(90).ToDuration() is 1m30s and
+// New(90 * time.Second).ToInt() is 90. A Policy may override it, see Options.
+var DurationUnit = time.Nanosecond
+
+var _ IConvertStrategy[time.Duration] = (*durationConverter)(nil)
+
+type durationConverter struct {
+	Converter[time.Duration]
+}
+
+// FromString accepts the format of time.ParseDuration ("1h30m"), ISO 8601
+// durations ("PT1H30M") and plain numbers counted in DurationUnit.
+func (c durationConverter) FromString(v Variant) (time.Duration, error) {
+	s := *(*string)(unsafe.Pointer(&v.Data))
+	d, err := parseDuration(s, v.durationUnit())
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+// FromBool is not supported, a boolean has no meaningful duration.
+func (c durationConverter) FromBool(v Variant) (time.Duration, error) {
+	return 0, conversionError(v, Duration, ErrUnsupported)
+}
+
+func (c durationConverter) FromInt(v Variant) (time.Duration, error) {
+	i, err := payloadInt(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	d, err := durationOf(v.durationUnit(), i)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromInt8(v Variant) (time.Duration, error) {
+	i, err := payloadInt8(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	d, err := durationOf(v.durationUnit(), int64(i))
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromInt16(v Variant) (time.Duration, error) {
+	i, err := payloadInt16(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	d, err := durationOf(v.durationUnit(), int64(i))
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromInt32(v Variant) (time.Duration, error) {
+	i, err := payloadInt32(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	d, err := durationOf(v.durationUnit(), int64(i))
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromInt64(v Variant) (time.Duration, error) {
+	i, err := payloadInt64(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	d, err := durationOf(v.durationUnit(), i)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromUint(v Variant) (time.Duration, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	if uint64(i) > math.MaxInt64 {
+		return 0, conversionError(v, Duration, ErrOverflow)
+	}
+	d, err := durationOf(v.durationUnit(), int64(i))
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromUint8(v Variant) (time.Duration, error) {
+	i, err := payloadUint8(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	d, err := durationOf(v.durationUnit(), int64(i))
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromUint16(v Variant) (time.Duration, error) {
+	i, err := payloadUint16(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	d, err := durationOf(v.durationUnit(), int64(i))
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromUint32(v Variant) (time.Duration, error) {
+	i, err := payloadUint32(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	d, err := durationOf(v.durationUnit(), int64(i))
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromUint64(v Variant) (time.Duration, error) {
+	i, err := payloadUint64(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	if uint64(i) > math.MaxInt64 {
+		return 0, conversionError(v, Duration, ErrOverflow)
+	}
+	d, err := durationOf(v.durationUnit(), int64(i))
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromUintptr(v Variant) (time.Duration, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	if uint64(i) > math.MaxInt64 {
+		return 0, conversionError(v, Duration, ErrOverflow)
+	}
+	d, err := durationOf(v.durationUnit(), int64(i))
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromFloat32(v Variant) (time.Duration, error) {
+	f, err := payloadFloat32(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	d, err := durationOfFloat(v.durationUnit(), float64(f))
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromFloat64(v Variant) (time.Duration, error) {
+	f, err := payloadFloat64(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	d, err := durationOfFloat(v.durationUnit(), f)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromComplex64(v Variant) (time.Duration, error) {
+	z, err := payloadComplex64(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	d, err := durationOfFloat(v.durationUnit(), float64(real(z)))
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromComplex128(v Variant) (time.Duration, error) {
+	z, err := payloadComplex128(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	d, err := durationOfFloat(v.durationUnit(), real(z))
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+// FromTime is not supported, a point in time is not a duration.
+func (c durationConverter) FromTime(v Variant) (time.Duration, error) {
+	return 0, conversionError(v, Duration, ErrUnsupported)
+}
+
+func (c durationConverter) FromDuration(v Variant) (time.Duration, error) {
+	d, err := payloadDuration(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromBigInt(v Variant) (time.Duration, error) {
+	b, err := payloadBigInt(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	if !b.IsInt64() {
+		return 0, conversionError(v, Duration, ErrOverflow)
+	}
+	d, err := durationOf(v.durationUnit(), b.Int64())
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromBigFloat(v Variant) (time.Duration, error) {
+	x, err := payloadBigFloat(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	f, _ := x.Float64()
+	d, err := durationOfFloat(v.durationUnit(), f)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	return d, nil
+}
+
+func (c durationConverter) FromDecimal(v Variant) (time.Duration, error) {
+	d, err := payloadDec(v)
+	if err != nil {
+		return 0, conversionError(v, Duration, err)
+	}
+	// scale by the unit before truncating, so that 1.5 seconds is exact
+	b := d.Mul(NewDec(int64(v.durationUnit()), 0)).integer()
+	if !b.IsInt64() {
+		return 0, conversionError(v, Duration, ErrOverflow)
+	}
+	return time.Duration(b.Int64()), nil
+}
+
+func newDurationConverter() IConvertStrategy[time.Duration] {
+	c := &durationConverter{}
+	c.m = map[Kind]func(v Variant) (time.Duration, error){
+		String:     c.FromString,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Duration:   c.FromDuration,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
+	}
+	return c
+}
+
+// durationOf returns n times unit.
+func durationOf(unit time.Duration, n int64) (time.Duration, error) {
+	if n > math.MaxInt64/int64(unit) || n < math.MinInt64/int64(unit) {
+		return 0, ErrOverflow
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// durationOfFloat returns f times unit, rounded to the nanosecond.
+func durationOfFloat(unit time.Duration, f float64) (time.Duration, error) {
+	n := math.Round(f * float64(unit))
+	if math.IsNaN(n) || n < math.MinInt64 || n >= math.MaxInt64 {
+		return 0, ErrOverflow
+	}
+	return time.Duration(n), nil
+}
+
+// parseDuration parses s in the format of time.ParseDuration, as an ISO 8601
+// duration or as a number counted in unit.
+func parseDuration(s string, unit time.Duration) (time.Duration, error) {
+	if strings.HasPrefix(strings.TrimLeft(s, "+-"), "P") {
+		return parseISODuration(s)
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return durationOf(unit, i)
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return durationOfFloat(unit, f)
+	}
+	return 0, ErrSyntax
+}
+
+// parseISODuration parses an ISO 8601 duration such as "PT1H30M", "P1DT12H"
+// or "-PT0.5S". Days are 24 hours and weeks 7 days long, years and months are
+// rejected since their length depends on the date they apply to.
+func parseISODuration(s string) (time.Duration, error) {
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, ErrSyntax
+	}
+	s = s[1:]
+
+	var d time.Duration
+	inTime := false
+	for s != "" {
+		if s[0] == 'T' {
+			if inTime || len(s) == 1 {
+				return 0, ErrSyntax
+			}
+			inTime = true
+			s = s[1:]
+			continue
+		}
+		i := 0
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.' || s[i] == ',') {
+			i++
+		}
+		if i == 0 || i == len(s) {
+			return 0, ErrSyntax
+		}
+		var unit time.Duration
+		switch designator := s[i]; {
+		case !inTime && designator == 'W':
+			unit = 7 * 24 * time.Hour
+		case !inTime && designator == 'D':
+			unit = 24 * time.Hour
+		case inTime && designator == 'H':
+			unit = time.Hour
+		case inTime && designator == 'M':
+			unit = time.Minute
+		case inTime && designator == 'S':
+			unit = time.Second
+		default:
+			return 0, ErrSyntax
+		}
+		x, err := scaleDuration(s[:i], unit)
+		if err != nil {
+			return 0, err
+		}
+		if d > math.MaxInt64-x {
+			return 0, ErrOverflow
+		}
+		d += x
+		s = s[i+1:]
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// scaleDuration returns the decimal number num, which may have a fractional
+// part separated by '.' or ',', times unit.
+func scaleDuration(num string, unit time.Duration) (time.Duration, error) {
+	whole, frac, _ := strings.Cut(strings.Replace(num, ",", ".", 1), ".")
+	if whole == "" && frac == "" {
+		return 0, ErrSyntax
+	}
+	var n int64
+	if whole != "" {
+		var err error
+		if n, err = strconv.ParseInt(whole, 10, 64); err != nil {
+			if strings.ContainsFunc(whole, func(r rune) bool { return r < '0' || r > '9' }) {
+				return 0, ErrSyntax
+			}
+			return 0, ErrOverflow
+		}
+	}
+	if n > math.MaxInt64/int64(unit) {
+		return 0, ErrOverflow
+	}
+	d := time.Duration(n) * unit
+	if frac != "" {
+		f, err := strconv.ParseFloat("0."+frac, 64)
+		if err != nil {
+			return 0, ErrSyntax
+		}
+		d += time.Duration(math.Round(f * float64(unit)))
+	}
+	return d, nil
+}
+
+// durationNumber returns the number of whole units of v in d, or ErrInexact
+// if v is strict and d is not a multiple of the unit.
+func durationNumber(v Variant, d time.Duration) (int64, error) {
+	unit := v.durationUnit()
+	if d%unit != 0 && v.options().Strict {
+		return 0, ErrInexact
+	}
+	return int64(d / unit), nil
+}
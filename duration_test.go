@@ -0,0 +1,75 @@
+package variant
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestVariant_ToDuration(t *testing.T) {
+	targets := []Pair[time.Duration]{
+		{"1h30m", 90 * time.Minute},
+		{"90s", 90 * time.Second},
+		{"-1.5h", -90 * time.Minute},
+		{"PT1H30M", 90 * time.Minute},
+		{"P1DT12H", 36 * time.Hour},
+		{"P2W", 14 * 24 * time.Hour},
+		{"-PT0.5S", -500 * time.Millisecond},
+		{"PT1,5S", 1500 * time.Millisecond},
+		{"P1Y", 0},
+		{"PT", 0},
+		{"P1H", 0},
+		{"abc", 0},
+		{"1000", 1000},
+		{true, 0},
+		{int8(-5), -5},
+		{uint32(10086), 10086},
+		{1.6, 2},
+		{uint64(math.MaxUint64), 0},
+		{time.Now(), 0},
+		{5 * time.Second, 5 * time.Second},
+	}
+	for _, pair := range targets {
+		t.Run("ToDuration", func(t *testing.T) {
+			v := New(pair.Key)
+			assert(v.ToDuration() == pair.Val, pair.Key, v.ToDuration())
+		})
+	}
+}
+
+func TestVariant_DurationUnit(t *testing.T) {
+	defer func(unit time.Duration) { DurationUnit = unit }(DurationUnit)
+	DurationUnit = time.Second
+
+	assert(New(90).ToDuration() == 90*time.Second)
+	assert(New("90").ToDuration() == 90*time.Second)
+	assert(New(1.5).ToDuration() == 1500*time.Millisecond)
+	assert(New(90*time.Second).ToInt() == 90)
+	assert(New(1500*time.Millisecond).ToFloat64() == 1.5)
+
+	_, err := New(int64(math.MaxInt64)).TryDuration()
+	assert(errors.Is(err, ErrOverflow), err)
+}
+
+func TestVariant_Duration(t *testing.T) {
+	d := 90 * time.Minute
+	v := New(&d)
+	assert(v.Type == Duration)
+	assert(v.ToString() == "1h30m0s", v.ToString())
+	assert(v.ToInt64() == int64(d))
+	assert(v.ToFloat64() == float64(d))
+	assert(v.ToUint64() == uint64(d))
+	assert(New(-d).ToUint() == 0)
+	assert(v.ToBool())
+
+	_, err := v.TryTime()
+	assert(errors.Is(err, ErrUnsupported), err)
+
+	b, err := json.Marshal(v)
+	assert(err == nil && string(b) == `"1h30m0s"`, string(b))
+	var u Variant
+	assert(json.Unmarshal(b, &u) == nil)
+	assert(u.ToDuration() == d)
+}
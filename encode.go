@@ -0,0 +1,313 @@
+package variant
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// Encode returns v as a tree of variants. Besides the values New stores
+// directly it handles named types, based on their underlying kind, types
+// implementing encoding.TextMarshaler, which become strings, and structs,
+// slices, arrays and maps, which become Map and List variants. A byte slice
+// or array becomes a Bytes variant, a nil pointer a typed null.
+//
+// The exported fields of a struct keep their declaration order and are named
+// by a tag of the form
+//
+//	`variant:"name,layout=2006-01-02,omitempty"`
+//
+// where layout is the layout of a time field and omitempty leaves out a field
+// holding the zero value of its type. A field tagged "-" is skipped and the
+// fields of embedded structs are promoted, as in Decode.
+//
+// Encode reports a *FieldError for values, such as channels and functions,
+// it cannot represent.
+func Encode(v any) (Variant, error) {
+	e := encoder{}
+	return e.encode(reflect.ValueOf(v), "")
+}
+
+type encoder struct {
+	seen map[visit]struct{} // pointers, maps and slices on the path being encoded
+}
+
+// A visit identifies a pointer, map or slice. A slice is identified by its
+// length too, as it shares the address of its subslices.
+type visit struct {
+	ptr uintptr
+	len int
+	typ reflect.Type
+}
+
+func visitOf(rv reflect.Value) visit {
+	key := visit{ptr: rv.Pointer(), typ: rv.Type()}
+	if rv.Kind() == reflect.Slice {
+		key.len = rv.Len()
+	}
+	return key
+}
+
+// enter adds the pointer, map or slice rv to the path being encoded, and
+// reports a *FieldError if it is already on it, which would be a cycle.
+func (e *encoder) enter(rv reflect.Value, path string) error {
+	key := visitOf(rv)
+	if _, ok := e.seen[key]; ok {
+		return &FieldError{Path: path, Err: fmt.Errorf("cannot encode cyclic %v: %w", rv.Type(), ErrUnsupported)}
+	}
+	if e.seen == nil {
+		e.seen = make(map[visit]struct{})
+	}
+	e.seen[key] = struct{}{}
+	return nil
+}
+
+// leave removes rv, entered last, from the path being encoded.
+func (e *encoder) leave(rv reflect.Value) {
+	delete(e.seen, visitOf(rv))
+}
+
+func (e *encoder) encode(rv reflect.Value, path string) (Variant, error) {
+	if !rv.IsValid() {
+		return NewNull(Invalid), nil
+	}
+	t := rv.Type()
+	if rv.CanInterface() {
+		// slices and maps are walked here, to report the elements that
+		// cannot be encoded and the cycles, interfaces are unwrapped first
+		if k := t.Kind(); k != reflect.Slice && k != reflect.Map && k != reflect.Interface {
+			if variant, ok := newVariant(rv.Interface()); ok {
+				return variant, nil
+			}
+		}
+		// pointers and interfaces are dereferenced first
+		if m, ok := rv.Interface().(encoding.TextMarshaler); ok && t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface {
+			text, err := m.MarshalText()
+			if err != nil {
+				return Nil, &FieldError{Path: path, Err: err}
+			}
+			return New(string(text)), nil
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return New(rv.Bool()), nil
+	case reflect.Int:
+		return New(int(rv.Int())), nil
+	case reflect.Int8:
+		return New(int8(rv.Int())), nil
+	case reflect.Int16:
+		return New(int16(rv.Int())), nil
+	case reflect.Int32:
+		return New(int32(rv.Int())), nil
+	case reflect.Int64:
+		return New(rv.Int()), nil
+	case reflect.Uint:
+		return New(uint(rv.Uint())), nil
+	case reflect.Uint8:
+		return New(uint8(rv.Uint())), nil
+	case reflect.Uint16:
+		return New(uint16(rv.Uint())), nil
+	case reflect.Uint32:
+		return New(uint32(rv.Uint())), nil
+	case reflect.Uint64:
+		return New(rv.Uint()), nil
+	case reflect.Uintptr:
+		return New(uintptr(rv.Uint())), nil
+	case reflect.Float32:
+		return New(float32(rv.Float())), nil
+	case reflect.Float64:
+		return New(rv.Float()), nil
+	case reflect.Complex64:
+		return New(complex64(rv.Complex())), nil
+	case reflect.Complex128:
+		return New(rv.Complex()), nil
+	case reflect.String:
+		return New(rv.String()), nil
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return NewNull(kindOf(t.Elem())), nil
+		}
+		if err := e.enter(rv, path); err != nil {
+			return Nil, err
+		}
+		defer e.leave(rv)
+		return e.encode(rv.Elem(), path)
+	case reflect.Interface:
+		return e.encode(rv.Elem(), path)
+	case reflect.Struct:
+		return e.encodeStruct(rv, path)
+	case reflect.Slice:
+		if rv.IsNil() {
+			return NewNull(Invalid), nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			return New(rv.Bytes()), nil
+		}
+		if err := e.enter(rv, path); err != nil {
+			return Nil, err
+		}
+		defer e.leave(rv)
+		return e.encodeList(rv, path)
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return New(b), nil
+		}
+		return e.encodeList(rv, path)
+	case reflect.Map:
+		if rv.IsNil() {
+			return NewNull(Invalid), nil
+		}
+		if err := e.enter(rv, path); err != nil {
+			return Nil, err
+		}
+		defer e.leave(rv)
+		return e.encodeMap(rv, path)
+	}
+	return Nil, &FieldError{Path: path, Err: fmt.Errorf("cannot encode %v: %w", t, ErrUnsupported)}
+}
+
+func (e *encoder) encodeList(rv reflect.Value, path string) (Variant, error) {
+	list := make([]Variant, rv.Len())
+	for i := range list {
+		elem, err := e.encode(rv.Index(i), index(path, i))
+		if err != nil {
+			return Nil, err
+		}
+		list[i] = elem
+	}
+	return New(list), nil
+}
+
+func (e *encoder) encodeMap(rv reflect.Value, path string) (Variant, error) {
+	entries := make(map[string]Variant, rv.Len())
+	for iter := rv.MapRange(); iter.Next(); {
+		key, err := e.encode(iter.Key(), path)
+		if err != nil {
+			return Nil, err
+		}
+		name := key.ToString()
+		if key.Type != String {
+			name = fmt.Sprint(iter.Key().Interface())
+		}
+		elem, err := e.encode(iter.Value(), member(path, name))
+		if err != nil {
+			return Nil, err
+		}
+		entries[name] = elem
+	}
+	return New(entries), nil
+}
+
+func (e *encoder) encodeStruct(rv reflect.Value, path string) (Variant, error) {
+	m := NewMap()
+	if err := e.encodeFields(rv, path, &m); err != nil {
+		return Nil, err
+	}
+	return m, nil
+}
+
+// encodeFields sets the fields of the struct rv in m, those of embedded
+// structs included.
+func (e *encoder) encodeFields(rv reflect.Value, path string, m *Variant) error {
+	t := rv.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup("variant")
+		if tag == "-" || !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		fv := rv.Field(i)
+		opts := parseTag(tag)
+		if f.Anonymous && !tagged && indirect(f.Type).Kind() == reflect.Struct {
+			if f.Type.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if err := e.encodeFields(fv, path, m); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.IsExported() || opts.omitEmpty && fv.IsZero() {
+			continue
+		}
+		name := opts.name
+		if name == "" {
+			name = f.Name
+		}
+		elem, err := e.encode(fv, member(path, name))
+		if err != nil {
+			return err
+		}
+		if opts.layout != "" {
+			elem.SetLayout(opts.layout)
+		}
+		m.Set(name, elem)
+	}
+	return nil
+}
+
+// kindOf returns the kind of variant Encode produces for values of type t,
+// Invalid when it depends on the value.
+func kindOf(t reflect.Type) Kind {
+	switch t {
+	case timeType:
+		return Time
+	case decType:
+		return Decimal
+	case durationType:
+		return Duration
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return Bool
+	case reflect.Int:
+		return Int
+	case reflect.Int8:
+		return Int8
+	case reflect.Int16:
+		return Int16
+	case reflect.Int32:
+		return Int32
+	case reflect.Int64:
+		return Int64
+	case reflect.Uint:
+		return Uint
+	case reflect.Uint8:
+		return Uint8
+	case reflect.Uint16:
+		return Uint16
+	case reflect.Uint32:
+		return Uint32
+	case reflect.Uint64:
+		return Uint64
+	case reflect.Uintptr:
+		return Uintptr
+	case reflect.Float32:
+		return Float32
+	case reflect.Float64:
+		return Float64
+	case reflect.Complex64:
+		return Complex64
+	case reflect.Complex128:
+		return Complex128
+	case reflect.String:
+		return String
+	case reflect.Struct:
+		return Map
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Bytes
+		}
+		return List
+	case reflect.Map:
+		return Map
+	}
+	return Invalid
+}
@@ -0,0 +1,100 @@
+package variant
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+type encodeItem struct {
+	SKU   string `variant:"sku"`
+	Price Dec    `variant:"price"`
+}
+
+type encodeOrder struct {
+	decodeBase
+	Status   Status
+	Label    Label     `variant:"label,omitempty"`
+	Placed   time.Time `variant:"placed,layout=2006-01-02"`
+	Items    []encodeItem
+	Counts   map[Label]int
+	Note     *string
+	IP       net.IP
+	Checksum [4]byte
+	Internal string `variant:"-"`
+	hidden   int
+}
+
+func TestEncode(t *testing.T) {
+	assert(New(Status(3)).Type == Int && New(Status(3)).ToInt() == 3)
+	assert(New(Label("x")).Type == String)
+	assert(New(Raw("ab")).Type == Bytes)
+	assert(New((*Status)(nil)).Equal(NewNull(Int)))
+	assert(New(net.ParseIP("10.0.0.1")).ToString() == "10.0.0.1")
+
+	order := encodeOrder{
+		decodeBase: decodeBase{ID: 9},
+		Status:     2,
+		Placed:     time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Items:      []encodeItem{{"a-1", NewDec(1999, 2)}},
+		Counts:     map[Label]int{"b": 2, "a": 1},
+		IP:         net.IPv4(127, 0, 0, 1),
+		Checksum:   [4]byte{0xde, 0xad, 0xbe, 0xef},
+		Internal:   "x",
+	}
+	v, err := Encode(order)
+	assert(err == nil, err)
+	assert(v.Type == Map)
+	assert(v.Get("Status").Type == Int)
+	assert(v.Get("placed").ToString() == "2024-05-01")
+	assert(v.Get("Note").Type == String && v.Get("Note").IsNull())
+	assert(v.Get("Checksum").Type == Bytes)
+
+	b, err := json.Marshal(v)
+	assert(err == nil, err)
+	want := `{"ID":9,"Status":2,"placed":"2024-05-01","Items":[{"sku":"a-1","price":19.99}],` +
+		`"Counts":{"a":1,"b":2},"Note":null,"IP":"127.0.0.1","Checksum":"3q2+7w=="}`
+	assert(string(b) == want, string(b))
+
+	// what Encode produces, Decode reads back
+	var back encodeOrder
+	assert(Decode(v, &back) == nil)
+	assert(back.ID == 9 && back.Status == 2 && back.Placed.Equal(order.Placed))
+	assert(len(back.Items) == 1 && back.Items[0].Price.String() == "19.99")
+	assert(back.Counts["b"] == 2 && back.Note == nil)
+	assert(back.IP.Equal(order.IP) && back.Checksum == order.Checksum)
+}
+
+func TestEncode_Errors(t *testing.T) {
+	_, err := Encode(map[string]any{"ok": 1, "ch": make(chan int)})
+	var fe *FieldError
+	assert(errors.As(err, &fe) && fe.Path == "ch", err)
+	assert(errors.Is(err, ErrUnsupported))
+	assert(New(func() {}).Type == Invalid)
+
+	type node struct{ Next *node }
+	n := &node{}
+	n.Next = n
+	_, err = Encode(n)
+	assert(errors.As(err, &fe) && fe.Path == "Next", err)
+
+	m := map[string]any{"ok": 1}
+	m["self"] = []any{m}
+	_, err = Encode(m)
+	assert(errors.As(err, &fe) && fe.Path == "self[0]" && errors.Is(err, ErrUnsupported), err)
+	l := []any{1, nil}
+	l[1] = l
+	_, err = Encode(l)
+	assert(errors.As(err, &fe) && fe.Path == "[1]", err)
+
+	// shared values and subslices are not cycles
+	shared := map[string]int{"a": 1}
+	l[1] = l[:1]
+	v, err := Encode(map[string]any{"x": shared, "y": shared, "l": l})
+	assert(err == nil && v.Get("y").Get("a").ToInt() == 1 && v.Get("l").Index(1).Len() == 1, v, err)
+
+	v, err = Encode(nil)
+	assert(err == nil && v.Type == Invalid)
+}
@@ -0,0 +1,59 @@
+package variant
+
+import (
+	"bytes"
+	"errors"
+)
+
+var (
+	// ErrSyntax indicates that the source value is not in a form the target
+	// type can be parsed from.
+	ErrSyntax = errors.New("invalid syntax")
+	// ErrOverflow indicates that the source value is out of range for the
+	// target type.
+	ErrOverflow = errors.New("value out of range")
+	// ErrUnsupported indicates that there is no strategy converting the source
+	// kind to the target type.
+	ErrUnsupported = errors.New("unsupported conversion")
+	// ErrTruncated indicates that the payload of the variant does not have the
+	// size or layout its kind requires.
+	ErrTruncated = errors.New("truncated payload")
+	// ErrInexact indicates that a strict conversion would lose the fractional
+	// part or the imaginary part of the source value.
+	ErrInexact = errors.New("inexact conversion")
+	// ErrNull indicates that the source variant is a typed null, which has no
+	// value to convert.
+	ErrNull = errors.New("null value")
+)
+
+// ConversionError records a failed conversion of a Variant.
+type ConversionError struct {
+	From Kind   // the kind of the source variant
+	To   Kind   // the kind of the target type, Interface for user types
+	Data []byte // the raw payload of the source variant
+	Err  error  // the reason the conversion failed (ErrSyntax, ErrOverflow, ...)
+}
+
+func (e *ConversionError) Error() string {
+	return "variant: cannot convert " + e.From.String() + " to " + e.To.String() + ": " + e.Err.Error()
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+// conversionError returns a *ConversionError describing why v could not be
+// converted to the kind to.
+func conversionError(v Variant, to Kind, err error) error {
+	return &ConversionError{From: v.Type, To: to, Data: bytes.Clone(v.Data), Err: err}
+}
+
+// retarget replaces the target kind of a *ConversionError reported by an
+// intermediate conversion, e.g. to int64 on behalf of ToInt8.
+func retarget(err error, to Kind) error {
+	var e *ConversionError
+	if errors.As(err, &e) {
+		e.To = to
+	}
+	return err
+}
@@ -0,0 +1,71 @@
+package variant
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestVariant_TryInt64(t *testing.T) {
+	targets := []Pair[error]{
+		{"0", nil},
+		{"-12.5", nil},
+		{"abc", ErrSyntax},
+		{"234.", ErrSyntax},
+		{".234", ErrSyntax},
+		{"", ErrSyntax},
+		{"9223372036854775808", ErrOverflow},
+		{"-9223372036854775809", ErrOverflow},
+		{nil, ErrUnsupported},
+		{Variant{Type: Int32, Data: []byte{0x01}}, ErrTruncated},
+		{Variant{Type: Time, Data: []byte{0x01}}, ErrTruncated},
+	}
+	for _, pair := range targets {
+		t.Run("TryInt64", func(t *testing.T) {
+			v, ok := pair.Key.(Variant)
+			if !ok {
+				v = New(pair.Key)
+			}
+			_, err := v.TryInt64()
+			assert(errors.Is(err, pair.Val), pair.Key, err)
+		})
+	}
+}
+
+func TestVariant_TryUint(t *testing.T) {
+	targets := []Pair[error]{
+		{"18", nil},
+		{"-1", ErrOverflow},
+		{"-x", ErrSyntax},
+		{int8(-1), ErrOverflow},
+		{math.MinInt64, ErrOverflow},
+		{-0.5, nil},
+		{-1.5, ErrOverflow},
+		{uint64(math.MaxUint64), nil},
+	}
+	for _, pair := range targets {
+		t.Run("TryUint", func(t *testing.T) {
+			_, err := New(pair.Key).TryUint()
+			assert(errors.Is(err, pair.Val), pair.Key, err)
+		})
+	}
+}
+
+func TestConversionError(t *testing.T) {
+	v := New("abc")
+	i, err := v.TryInt()
+	assert(i == 0)
+	var e *ConversionError
+	assert(errors.As(err, &e))
+	assert(e.From == String && e.To == Int && string(e.Data) == "abc")
+	assert(e.Error() == "variant: cannot convert string to int: invalid syntax", e.Error())
+
+	_, err = New(1.5).TryTime()
+	assert(err == nil)
+	_, err = New("2024-06-20").TryTime()
+	assert(errors.Is(err, ErrSyntax))
+	_, err = Nil.TryString()
+	assert(errors.Is(err, ErrUnsupported))
+	_, err = Nil.TryBool()
+	assert(errors.Is(err, ErrUnsupported))
+}
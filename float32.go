@@ -1,10 +1,10 @@
 package variant
 
 import (
-	"encoding/binary"
+	"errors"
 	"math"
+	"math/big"
 	"strconv"
-	"time"
 	"unsafe"
 )
 
@@ -14,125 +14,235 @@ type float32Converter struct {
 	Converter[float32]
 }
 
-func (c float32Converter) FromString(v Variant) float32 {
-	s := *(*string)(unsafe.Pointer(&v.Data))
-	f64, err := strconv.ParseFloat(s, 32)
+func (c float32Converter) FromString(v Variant) (float32, error) {
+	b, err := v.numeral()
 	if err != nil {
-		return 0
+		return 0, conversionError(v, Float32, err)
 	}
-	return float32(f64)
+	f64, err := strconv.ParseFloat(*(*string)(unsafe.Pointer(&b)), 32)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) && v.options().Saturate {
+			return narrowFloat(v, Float32, float32(f64), true, false)
+		}
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, conversionError(v, Float32, ErrOverflow)
+		}
+		return 0, conversionError(v, Float32, ErrSyntax)
+	}
+	return float32(f64), nil
 }
 
-func (c float32Converter) FromBool(v Variant) float32 {
+func (c float32Converter) FromBool(v Variant) (float32, error) {
 	if len(v.Data) == 0 || v.Data[0] == 0x00 {
-		return 0
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func (c float32Converter) FromInt(v Variant) (float32, error) {
+	i, err := payloadInt(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
+	}
+	return intFloat[float32](v, Float32, i)
+}
+
+func (c float32Converter) FromInt8(v Variant) (float32, error) {
+	i, err := payloadInt8(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
+	}
+	return float32(i), nil
+}
+
+func (c float32Converter) FromInt16(v Variant) (float32, error) {
+	i, err := payloadInt16(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
+	}
+	return float32(i), nil
+}
+
+func (c float32Converter) FromInt32(v Variant) (float32, error) {
+	i, err := payloadInt32(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
+	}
+	return intFloat[float32](v, Float32, i)
+}
+
+func (c float32Converter) FromInt64(v Variant) (float32, error) {
+	i, err := payloadInt64(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
+	}
+	return intFloat[float32](v, Float32, i)
+}
+
+func (c float32Converter) FromUint(v Variant) (float32, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
 	}
-	return 1
+	return intFloat[float32](v, Float32, i)
 }
 
-func (c float32Converter) FromInt(v Variant) float32 {
-	var i int
-	if len(v.Data) == 4 && intSize == 32 {
-		i = int(binary.BigEndian.Uint32(v.Data))
-	} else if len(v.Data) == 8 && intSize == 64 {
-		i = int(binary.BigEndian.Uint64(v.Data))
+func (c float32Converter) FromUint8(v Variant) (float32, error) {
+	i, err := payloadUint8(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
 	}
-	return float32(i)
+	return float32(i), nil
 }
 
-func (c float32Converter) FromInt8(v Variant) float32 {
-	var i int8
-	if len(v.Data) == 1 {
-		i = int8(v.Data[0])
+func (c float32Converter) FromUint16(v Variant) (float32, error) {
+	i, err := payloadUint16(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
 	}
-	return float32(i)
+	return float32(i), nil
 }
 
-func (c float32Converter) FromInt16(v Variant) float32 {
-	var i int16
-	if len(v.Data) == 2 {
-		i = int16(binary.BigEndian.Uint16(v.Data))
+func (c float32Converter) FromUint32(v Variant) (float32, error) {
+	i, err := payloadUint32(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
+	}
+	return intFloat[float32](v, Float32, i)
+}
+
+func (c float32Converter) FromUint64(v Variant) (float32, error) {
+	i, err := payloadUint64(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
 	}
-	return float32(i)
+	return intFloat[float32](v, Float32, i)
 }
 
-func (c float32Converter) FromInt32(v Variant) float32 {
-	return float32(int32(binary.BigEndian.Uint32(v.Data)))
+func (c float32Converter) FromUintptr(v Variant) (float32, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
+	}
+	return intFloat[float32](v, Float32, i)
+}
+
+func (c float32Converter) FromFloat32(v Variant) (float32, error) {
+	f, err := payloadFloat32(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
+	}
+	return f, nil
 }
 
-func (c float32Converter) FromInt64(v Variant) float32 {
-	return float32(int64(binary.BigEndian.Uint64(v.Data)))
+func (c float32Converter) FromFloat64(v Variant) (float32, error) {
+	f, err := payloadFloat64(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
+	}
+	return float64Float32(v, f)
 }
 
-func (c float32Converter) FromUint(v Variant) float32 {
-	var i uint
-	if intSize == 32 {
-		i = uint(binary.BigEndian.Uint32(v.Data))
-	} else if intSize == 64 {
-		i = uint(binary.BigEndian.Uint64(v.Data))
+func (c float32Converter) FromComplex64(v Variant) (float32, error) {
+	z, err := payloadComplex64(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
 	}
-	return float32(i)
+	f := real(z)
+	return f, nil
 }
 
-func (c float32Converter) FromUint8(v Variant) float32 {
-	return float32(v.Data[0])
+func (c float32Converter) FromComplex128(v Variant) (float32, error) {
+	z, err := payloadComplex128(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
+	}
+	return float64Float32(v, real(z))
 }
 
-func (c float32Converter) FromUint16(v Variant) float32 {
-	return float32(binary.BigEndian.Uint16(v.Data))
+// float64Float32 narrows f, the value of v, to float32 as narrowFloat checks
+// it. A value out of range is an infinity otherwise.
+func float64Float32(v Variant, f float64) (float32, error) {
+	r := float32(f)
+	overflow := math.IsInf(float64(r), 0) && !math.IsInf(f, 0)
+	return narrowFloat(v, Float32, r, overflow, float64(r) == f || math.IsNaN(f))
 }
 
-func (c float32Converter) FromUint32(v Variant) float32 {
-	return float32(binary.BigEndian.Uint32(v.Data))
+func (c float32Converter) FromTime(v Variant) (float32, error) {
+	t, err := payloadTime(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
+	}
+	return float32(epochFloat(v, t)), nil
 }
 
-func (c float32Converter) FromUint64(v Variant) float32 {
-	return float32(binary.BigEndian.Uint64(v.Data))
+func (c float32Converter) FromDuration(v Variant) (float32, error) {
+	d, err := payloadDuration(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
+	}
+	return float32(float64(d) / float64(v.durationUnit())), nil
 }
 
-func (c float32Converter) FromFloat32(v Variant) float32 {
-	var f float32
-	if len(v.Data) == 4 {
-		f = math.Float32frombits(binary.BigEndian.Uint32(v.Data))
+func (c float32Converter) FromBigInt(v Variant) (float32, error) {
+	b, err := payloadBigInt(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
 	}
-	return f
+	f, acc := new(big.Float).SetInt(b).Float32()
+	overflow := math.IsInf(float64(f), 0)
+	if overflow && !v.options().Saturate {
+		return 0, conversionError(v, Float32, ErrOverflow)
+	}
+	return narrowFloat(v, Float32, f, overflow, acc == big.Exact)
 }
 
-func (c float32Converter) FromFloat64(v Variant) float32 {
-	var f float64
-	if len(v.Data) == 8 {
-		f = math.Float64frombits(binary.BigEndian.Uint64(v.Data))
+func (c float32Converter) FromBigFloat(v Variant) (float32, error) {
+	x, err := payloadBigFloat(v)
+	if err != nil {
+		return 0, conversionError(v, Float32, err)
+	}
+	f, acc := x.Float32()
+	overflow := math.IsInf(float64(f), 0) && !x.IsInf()
+	if overflow && !v.options().Saturate {
+		return 0, conversionError(v, Float32, ErrOverflow)
 	}
-	return float32(f)
+	return narrowFloat(v, Float32, f, overflow, acc == big.Exact)
 }
 
-func (c float32Converter) FromTime(v Variant) float32 {
-	var t time.Time
-	err := t.UnmarshalBinary(v.Data)
+func (c float32Converter) FromDecimal(v Variant) (float32, error) {
+	f, err := payloadDecFloat(v, 32)
 	if err != nil {
-		return 0
+		return 0, conversionError(v, Float32, err)
 	}
-	return float32(t.UnixNano())
+	return float32(f), nil
 }
 
 func newFloat32Converter() IConvertStrategy[float32] {
 	c := &float32Converter{}
-	c.m = map[Kind]func(v Variant) float32{
-		String:  c.FromString,
-		Bool:    c.FromBool,
-		Int:     c.FromInt,
-		Int8:    c.FromInt8,
-		Int16:   c.FromInt16,
-		Int32:   c.FromInt32,
-		Int64:   c.FromInt64,
-		Uint:    c.FromUint,
-		Uint8:   c.FromUint8,
-		Uint16:  c.FromUint16,
-		Uint32:  c.FromUint32,
-		Uint64:  c.FromUint64,
-		Float32: c.FromFloat32,
-		Float64: c.FromFloat64,
-		Time:    c.FromTime,
+	c.m = map[Kind]func(v Variant) (float32, error){
+		String:     c.FromString,
+		Bool:       c.FromBool,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Time:       c.FromTime,
+		Duration:   c.FromDuration,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
 	}
 	return c
 }
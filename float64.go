@@ -1,10 +1,10 @@
 package variant
 
 import (
-	"encoding/binary"
+	"errors"
 	"math"
+	"math/big"
 	"strconv"
-	"time"
 	"unsafe"
 )
 
@@ -14,130 +14,242 @@ type float64Converter struct {
 	Converter[float64]
 }
 
-func (c float64Converter) FromString(v Variant) float64 {
-	s := *(*string)(unsafe.Pointer(&v.Data))
-	f64, err := strconv.ParseFloat(s, 64)
+func (c float64Converter) FromString(v Variant) (float64, error) {
+	b, err := v.numeral()
 	if err != nil {
-		return 0
+		return 0, conversionError(v, Float64, err)
 	}
-	return f64
+	f64, err := strconv.ParseFloat(*(*string)(unsafe.Pointer(&b)), 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) && v.options().Saturate {
+			return narrowFloat(v, Float64, float64(f64), true, false)
+		}
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, conversionError(v, Float64, ErrOverflow)
+		}
+		return 0, conversionError(v, Float64, ErrSyntax)
+	}
+	return float64(f64), nil
 }
 
-func (c float64Converter) FromBool(v Variant) float64 {
+func (c float64Converter) FromBool(v Variant) (float64, error) {
 	if len(v.Data) == 0 || v.Data[0] == 0x00 {
-		return 0
+		return 0, nil
 	}
-	return 1
+	return 1, nil
 }
 
-func (c float64Converter) FromInt(v Variant) float64 {
-	var i int
-	if len(v.Data) == 4 && intSize == 32 {
-		i = int(binary.BigEndian.Uint32(v.Data))
-	} else if len(v.Data) == 8 && intSize == 64 {
-		i = int(binary.BigEndian.Uint64(v.Data))
+func (c float64Converter) FromInt(v Variant) (float64, error) {
+	i, err := payloadInt(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
 	}
-	return float64(i)
+	return intFloat[float64](v, Float64, i)
 }
 
-func (c float64Converter) FromInt8(v Variant) float64 {
-	var i int8
-	if len(v.Data) == 1 {
-		i = int8(v.Data[0])
+func (c float64Converter) FromInt8(v Variant) (float64, error) {
+	i, err := payloadInt8(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
 	}
-	return float64(i)
+	return float64(i), nil
 }
 
-func (c float64Converter) FromInt16(v Variant) float64 {
-	var i int16
-	if len(v.Data) == 2 {
-		i = int16(binary.BigEndian.Uint16(v.Data))
+func (c float64Converter) FromInt16(v Variant) (float64, error) {
+	i, err := payloadInt16(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
 	}
-	return float64(i)
+	return float64(i), nil
 }
 
-func (c float64Converter) FromInt32(v Variant) float64 {
-	return float64(int32(binary.BigEndian.Uint32(v.Data)))
+func (c float64Converter) FromInt32(v Variant) (float64, error) {
+	i, err := payloadInt32(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	return float64(i), nil
 }
 
-func (c float64Converter) FromInt64(v Variant) float64 {
-	return float64(int64(binary.BigEndian.Uint64(v.Data)))
+func (c float64Converter) FromInt64(v Variant) (float64, error) {
+	i, err := payloadInt64(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	return intFloat[float64](v, Float64, i)
 }
 
-func (c float64Converter) FromUint(v Variant) float64 {
-	var i uint
-	if intSize == 32 {
-		i = uint(binary.BigEndian.Uint32(v.Data))
-	} else if intSize == 64 {
-		i = uint(binary.BigEndian.Uint64(v.Data))
+func (c float64Converter) FromUint(v Variant) (float64, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
 	}
-	return float64(i)
+	return intFloat[float64](v, Float64, i)
 }
 
-func (c float64Converter) FromUint8(v Variant) float64 {
-	return float64(v.Data[0])
+func (c float64Converter) FromUint8(v Variant) (float64, error) {
+	i, err := payloadUint8(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	return float64(i), nil
 }
 
-func (c float64Converter) FromUint16(v Variant) float64 {
-	return float64(binary.BigEndian.Uint16(v.Data))
+func (c float64Converter) FromUint16(v Variant) (float64, error) {
+	i, err := payloadUint16(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	return float64(i), nil
 }
 
-func (c float64Converter) FromUint32(v Variant) float64 {
-	return float64(binary.BigEndian.Uint32(v.Data))
+func (c float64Converter) FromUint32(v Variant) (float64, error) {
+	i, err := payloadUint32(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	return float64(i), nil
+}
+
+func (c float64Converter) FromUint64(v Variant) (float64, error) {
+	i, err := payloadUint64(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	return intFloat[float64](v, Float64, i)
+}
+
+func (c float64Converter) FromUintptr(v Variant) (float64, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	return intFloat[float64](v, Float64, i)
+}
+
+func (c float64Converter) FromFloat32(v Variant) (float64, error) {
+	f, err := payloadFloat32(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	// go through the shortest decimal representation so that float32(0.1)
+	// becomes 0.1 rather than 0.10000000149011612
+	str := strconv.FormatFloat(float64(f), 'g', -1, 32)
+	f64, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, conversionError(v, Float64, ErrSyntax)
+	}
+	return f64, nil
 }
 
-func (c float64Converter) FromUint64(v Variant) float64 {
-	return float64(binary.BigEndian.Uint64(v.Data))
+func (c float64Converter) FromFloat64(v Variant) (float64, error) {
+	f, err := payloadFloat64(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	return f, nil
 }
 
-func (c float64Converter) FromFloat32(v Variant) float64 {
-	var f float32
-	if len(v.Data) == 4 {
-		f = math.Float32frombits(binary.BigEndian.Uint32(v.Data))
+func (c float64Converter) FromComplex64(v Variant) (float64, error) {
+	z, err := payloadComplex64(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
 	}
+	f := real(z)
+	// go through the shortest decimal representation so that float32(0.1)
+	// becomes 0.1 rather than 0.10000000149011612
 	str := strconv.FormatFloat(float64(f), 'g', -1, 32)
 	f64, err := strconv.ParseFloat(str, 64)
 	if err != nil {
-		return 0
+		return 0, conversionError(v, Float64, ErrSyntax)
 	}
-	return f64
+	return f64, nil
 }
 
-func (c float64Converter) FromFloat64(v Variant) float64 {
-	var f float64
-	if len(v.Data) == 8 {
-		f = math.Float64frombits(binary.BigEndian.Uint64(v.Data))
+func (c float64Converter) FromComplex128(v Variant) (float64, error) {
+	z, err := payloadComplex128(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	f := real(z)
+	return f, nil
+}
+
+func (c float64Converter) FromTime(v Variant) (float64, error) {
+	t, err := payloadTime(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	return epochFloat(v, t), nil
+}
+
+func (c float64Converter) FromDuration(v Variant) (float64, error) {
+	d, err := payloadDuration(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	return float64(d) / float64(v.durationUnit()), nil
+}
+
+func (c float64Converter) FromBigInt(v Variant) (float64, error) {
+	b, err := payloadBigInt(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	f, acc := new(big.Float).SetInt(b).Float64()
+	overflow := math.IsInf(f, 0)
+	if overflow && !v.options().Saturate {
+		return 0, conversionError(v, Float64, ErrOverflow)
+	}
+	return narrowFloat(v, Float64, f, overflow, acc == big.Exact)
+}
+
+func (c float64Converter) FromBigFloat(v Variant) (float64, error) {
+	x, err := payloadBigFloat(v)
+	if err != nil {
+		return 0, conversionError(v, Float64, err)
+	}
+	f, acc := x.Float64()
+	overflow := math.IsInf(f, 0) && !x.IsInf()
+	if overflow && !v.options().Saturate {
+		return 0, conversionError(v, Float64, ErrOverflow)
 	}
-	return f
+	return narrowFloat(v, Float64, f, overflow, acc == big.Exact)
 }
 
-func (c float64Converter) FromTime(v Variant) float64 {
-	var t time.Time
-	err := t.UnmarshalBinary(v.Data)
+func (c float64Converter) FromDecimal(v Variant) (float64, error) {
+	f, err := payloadDecFloat(v, 64)
 	if err != nil {
-		return 0
+		return 0, conversionError(v, Float64, err)
 	}
-	return float64(t.UnixNano())
+	return f, nil
 }
 
 func newFloat64Converter() IConvertStrategy[float64] {
 	c := &float64Converter{}
-	c.m = map[Kind]func(v Variant) float64{
-		String:  c.FromString,
-		Bool:    c.FromBool,
-		Int:     c.FromInt,
-		Int8:    c.FromInt8,
-		Int16:   c.FromInt16,
-		Int32:   c.FromInt32,
-		Int64:   c.FromInt64,
-		Uint:    c.FromUint,
-		Uint8:   c.FromUint8,
-		Uint16:  c.FromUint16,
-		Uint32:  c.FromUint32,
-		Uint64:  c.FromUint64,
-		Float32: c.FromFloat32,
-		Float64: c.FromFloat64,
-		Time:    c.FromTime,
+	c.m = map[Kind]func(v Variant) (float64, error){
+		String:     c.FromString,
+		Bool:       c.FromBool,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Time:       c.FromTime,
+		Duration:   c.FromDuration,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
 	}
 	return c
 }
@@ -1,211 +1,256 @@
 package variant
 
-import (
-	"encoding/binary"
-	"math"
-	"time"
-)
-
 var _ IConvertStrategy[int] = (*intConverter)(nil)
 
 type intConverter struct {
 	Converter[int]
 }
 
-func (c intConverter) FromString(v Variant) int {
-
-	tail := []byte{}
-	for index, ch := range v.Data {
-		if ch == '.' {
-			tail = v.Data[index+1:]
-			if len(tail) == 0 {
-				return 0
-			}
-			v.Data = v.Data[:index]
-			break
-		}
-	}
-	// check if tail is not a number
-	for _, ch := range tail {
-		if ch < '0' || ch > '9' {
-			return 0
-		}
-	}
-
-	start := 0
-	sLen := len(v.Data)
-	if sLen == 0 {
-		return 0
-	}
-	if v.Data[0] == '-' || v.Data[0] == '+' {
-		start = 1
-		sLen -= 1
-		if len(v.Data) < 2 {
-			return 0
-		}
-	}
-
-	n := 0
-
-	if (intSize == 32 && sLen < 10) || (intSize == 64 && sLen < 19) {
-		for _, ch := range v.Data[start:] {
-			ch -= '0'
-			if ch > 9 {
-				return 0
-			}
-			n = n*10 + int(ch)
-		}
-	}
-
-	sub := 0
-	if (intSize == 32 && sLen == 10) || (intSize == 64 && sLen == 19) {
-		for _, ch := range v.Data[start : len(v.Data)-1] {
-			ch -= '0'
-			if ch > 9 {
-				return 0
-			}
-			n = n*10 + int(ch)
-		}
-
-		cutoff := maxInt / 10
-		remainder := maxInt % 10
-		ch := v.Data[len(v.Data)-1] - '0'
-		if ch > 9 {
-			return 0
-		}
-		digit := int(ch)
-		if v.Data[0] == '-' && cutoff == n && remainder == digit-1 {
-			sub = 1
-			n = n*10 + remainder
-		} else if (cutoff == n && remainder >= digit) || cutoff > n {
-			n = n*10 + digit
-		} else {
-			return 0
-		}
-	}
-
-	if v.Data[0] == '-' {
-		n = -n - sub
-	}
-	return n
-}
-
-func (c intConverter) FromBool(v Variant) int {
+func (c intConverter) FromString(v Variant) (int, error) {
+	s, err := v.numeral()
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	i, err := parseInt(s, intSize, v.options())
+	if err == ErrOverflow {
+		return saturate[int](v, Int, negative(s))
+	}
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	return int(i), nil
+}
+
+func (c intConverter) FromBool(v Variant) (int, error) {
 	if len(v.Data) == 0 || v.Data[0] == 0x00 {
-		return 0
+		return 0, nil
 	}
-	return 1
+	return 1, nil
 }
 
-func (c intConverter) FromInt(v Variant) int {
-	var i int
-	if len(v.Data) == 4 && intSize == 32 {
-		i = int(binary.BigEndian.Uint32(v.Data))
-	} else if len(v.Data) == 8 && intSize == 64 {
-		i = int(binary.BigEndian.Uint64(v.Data))
+func (c intConverter) FromInt(v Variant) (int, error) {
+	i, err := payloadInt(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
 	}
-	return i
+	if i < -maxInt-1 || i > maxInt {
+		return saturate[int](v, Int, i < 0)
+	}
+	return int(i), nil
 }
 
-func (c intConverter) FromInt8(v Variant) int {
-	var i int8
-	if len(v.Data) == 1 {
-		i = int8(v.Data[0])
+func (c intConverter) FromInt8(v Variant) (int, error) {
+	i, err := payloadInt8(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
 	}
-	return int(i)
+	return int(i), nil
 }
 
-func (c intConverter) FromInt16(v Variant) int {
-	var i int16
-	if len(v.Data) == 2 {
-		i = int16(binary.BigEndian.Uint16(v.Data))
+func (c intConverter) FromInt16(v Variant) (int, error) {
+	i, err := payloadInt16(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
 	}
-	return int(i)
+	return int(i), nil
 }
 
-func (c intConverter) FromInt32(v Variant) int {
-	return int(int32(binary.BigEndian.Uint32(v.Data)))
+func (c intConverter) FromInt32(v Variant) (int, error) {
+	i, err := payloadInt32(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	return int(i), nil
 }
 
-func (c intConverter) FromInt64(v Variant) int {
-	return int(int64(binary.BigEndian.Uint64(v.Data)))
+func (c intConverter) FromInt64(v Variant) (int, error) {
+	i, err := payloadInt64(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	if i < -maxInt-1 || i > maxInt {
+		return saturate[int](v, Int, i < 0)
+	}
+	return int(i), nil
 }
 
-func (c intConverter) FromUint(v Variant) int {
-	var i uint
-	if intSize == 32 {
-		i = uint(binary.BigEndian.Uint32(v.Data))
-		if i > 1<<31-1 {
-			return 0
-		}
-	} else if intSize == 64 {
-		i = uint(binary.BigEndian.Uint64(v.Data))
-		if i > 1<<63-1 {
-			return 0
-		}
+func (c intConverter) FromUint(v Variant) (int, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	if i > maxInt {
+		return saturate[int](v, Int, false)
 	}
-	return int(i)
+	return int(i), nil
 }
 
-func (c intConverter) FromUint8(v Variant) int {
-	return int(v.Data[0])
+func (c intConverter) FromUint8(v Variant) (int, error) {
+	i, err := payloadUint8(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	return int(i), nil
 }
 
-func (c intConverter) FromUint16(v Variant) int {
-	return int(binary.BigEndian.Uint16(v.Data))
+func (c intConverter) FromUint16(v Variant) (int, error) {
+	i, err := payloadUint16(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	return int(i), nil
 }
 
-func (c intConverter) FromUint32(v Variant) int {
-	return int(binary.BigEndian.Uint32(v.Data))
+func (c intConverter) FromUint32(v Variant) (int, error) {
+	i, err := payloadUint32(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	if uint64(i) > maxInt {
+		return saturate[int](v, Int, false)
+	}
+	return int(i), nil
 }
 
-func (c intConverter) FromUint64(v Variant) int {
-	return int(binary.BigEndian.Uint64(v.Data))
+func (c intConverter) FromUint64(v Variant) (int, error) {
+	i, err := payloadUint64(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	if i > maxInt {
+		return saturate[int](v, Int, false)
+	}
+	return int(i), nil
 }
 
-func (c intConverter) FromFloat32(v Variant) int {
-	var f float32
-	if len(v.Data) == 4 {
-		f = math.Float32frombits(binary.BigEndian.Uint32(v.Data))
+func (c intConverter) FromUintptr(v Variant) (int, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
 	}
-	return int(f)
+	if i > maxInt {
+		return saturate[int](v, Int, false)
+	}
+	return int(i), nil
 }
 
-func (c intConverter) FromFloat64(v Variant) int {
-	var f float64
-	if len(v.Data) == 8 {
-		f = math.Float64frombits(binary.BigEndian.Uint64(v.Data))
+func (c intConverter) FromFloat32(v Variant) (int, error) {
+	f, err := payloadFloat32(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
 	}
-	return int(f)
+	return floatInt[int](v, Int, float64(f))
 }
 
-func (c intConverter) FromTime(v Variant) int {
-	var t time.Time
-	err := t.UnmarshalBinary(v.Data)
+func (c intConverter) FromFloat64(v Variant) (int, error) {
+	f, err := payloadFloat64(v)
 	if err != nil {
-		return 0
+		return 0, conversionError(v, Int, err)
+	}
+	return floatInt[int](v, Int, f)
+}
+
+func (c intConverter) FromComplex64(v Variant) (int, error) {
+	z, err := payloadComplex64(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	return complexInt[int](v, Int, complex128(z))
+}
+
+func (c intConverter) FromComplex128(v Variant) (int, error) {
+	z, err := payloadComplex128(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	return complexInt[int](v, Int, z)
+}
+
+func (c intConverter) FromTime(v Variant) (int, error) {
+	t, err := payloadTime(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	n := epochNumber(v, t)
+	if n < -maxInt-1 || n > maxInt {
+		return saturate[int](v, Int, n < 0)
+	}
+	return int(n), nil
+}
+
+func (c intConverter) FromDuration(v Variant) (int, error) {
+	d, err := payloadDuration(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	n, err := durationNumber(v, d)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	if n < -maxInt-1 || n > maxInt {
+		return saturate[int](v, Int, n < 0)
+	}
+	return int(n), nil
+}
+
+func (c intConverter) FromBigInt(v Variant) (int, error) {
+	b, err := payloadBigInt(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	if !b.IsInt64() || int64(int(b.Int64())) != b.Int64() {
+		return saturate[int](v, Int, b.Sign() < 0)
+	}
+	return int(b.Int64()), nil
+}
+
+func (c intConverter) FromBigFloat(v Variant) (int, error) {
+	b, err := payloadBigFloatInt(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	if !b.IsInt64() || int64(int(b.Int64())) != b.Int64() {
+		return saturate[int](v, Int, b.Sign() < 0)
+	}
+	return int(b.Int64()), nil
+}
+
+func (c intConverter) FromDecimal(v Variant) (int, error) {
+	b, err := payloadDecInt(v)
+	if err != nil {
+		return 0, conversionError(v, Int, err)
+	}
+	if !b.IsInt64() || int64(int(b.Int64())) != b.Int64() {
+		return saturate[int](v, Int, b.Sign() < 0)
 	}
-	return int(t.UnixNano())
+	return int(b.Int64()), nil
 }
 
 func newIntConverter() IConvertStrategy[int] {
 	c := &intConverter{}
-	c.m = map[Kind]func(v Variant) int{
-		String:  c.FromString,
-		Bool:    c.FromBool,
-		Int:     c.FromInt,
-		Int8:    c.FromInt8,
-		Int16:   c.FromInt16,
-		Int32:   c.FromInt32,
-		Int64:   c.FromInt64,
-		Uint:    c.FromUint,
-		Uint8:   c.FromUint8,
-		Uint16:  c.FromUint16,
-		Uint32:  c.FromUint32,
-		Uint64:  c.FromUint64,
-		Float32: c.FromFloat32,
-		Float64: c.FromFloat64,
-		Time:    c.FromTime,
+	c.m = map[Kind]func(v Variant) (int, error){
+		String:     c.FromString,
+		Bool:       c.FromBool,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Time:       c.FromTime,
+		Duration:   c.FromDuration,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
 	}
 	return c
 }
@@ -1,10 +1,6 @@
 package variant
 
-import (
-	"encoding/binary"
-	"math"
-	"time"
-)
+import "math"
 
 var _ IConvertStrategy[int64] = (*int64Converter)(nil)
 
@@ -12,199 +8,235 @@ type int64Converter struct {
 	Converter[int64]
 }
 
-func (c int64Converter) FromString(v Variant) int64 {
-	tail := []byte{}
-	for index, ch := range v.Data {
-		if ch == '.' {
-			tail = v.Data[index+1:]
-			if len(tail) == 0 {
-				return 0
-			}
-			v.Data = v.Data[:index]
-			break
-		}
-	}
-	// check if tail is not a number
-	for _, ch := range tail {
-		if ch < '0' || ch > '9' {
-			return 0
-		}
-	}
-
-	start := 0
-	sLen := len(v.Data)
-	if sLen == 0 {
-		return 0
-	}
-	if v.Data[0] == '-' || v.Data[0] == '+' {
-		start = 1
-		sLen -= 1
-		if len(v.Data) < 2 {
-			return 0
-		}
-	}
-
-	var n int64 = 0
-
-	if (intSize == 32 && sLen < 10) || (intSize == 64 && sLen < 19) {
-		for _, ch := range v.Data[start:] {
-			ch -= '0'
-			if ch > 9 {
-				return 0
-			}
-			n = n*10 + int64(ch)
-		}
-	}
-
-	var sub int64 = 0
-	if (intSize == 32 && sLen == 10) || (intSize == 64 && sLen == 19) {
-		for _, ch := range v.Data[start : len(v.Data)-1] {
-			ch -= '0'
-			if ch > 9 {
-				return 0
-			}
-			n = n*10 + int64(ch)
-		}
-
-		var cutoff int64 = maxInt / 10
-		var remainder int64 = maxInt % 10
-		ch := v.Data[len(v.Data)-1] - '0'
-		if ch > 9 {
-			return 0
-		}
-		digit := int64(ch)
-		if v.Data[0] == '-' && cutoff == n && remainder == digit-1 {
-			sub = 1
-			n = n*10 + remainder
-		} else if (cutoff == n && remainder >= digit) || cutoff > n {
-			n = n*10 + digit
-		} else {
-			return 0
-		}
-	}
-
-	if v.Data[0] == '-' {
-		n = -n - sub
-	}
-	return n
-}
-
-func (c int64Converter) FromBool(v Variant) int64 {
+func (c int64Converter) FromString(v Variant) (int64, error) {
+	s, err := v.numeral()
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	i, err := parseInt(s, 64, v.options())
+	if err == ErrOverflow {
+		return saturate[int64](v, Int64, negative(s))
+	}
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	return i, nil
+}
+
+func (c int64Converter) FromBool(v Variant) (int64, error) {
 	if len(v.Data) == 0 || v.Data[0] == 0x00 {
-		return 0
+		return 0, nil
 	}
-	return 1
+	return 1, nil
 }
 
-func (c int64Converter) FromInt(v Variant) int64 {
-	var i int64
-	if len(v.Data) == 4 && intSize == 32 {
-		i = int64(binary.BigEndian.Uint32(v.Data))
-	} else if len(v.Data) == 8 && intSize == 64 {
-		i = int64(binary.BigEndian.Uint64(v.Data))
+func (c int64Converter) FromInt(v Variant) (int64, error) {
+	i, err := payloadInt(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
 	}
-	return i
+	return int64(i), nil
 }
 
-func (c int64Converter) FromInt8(v Variant) int64 {
-	var i int8
-	if len(v.Data) == 1 {
-		i = int8(v.Data[0])
+func (c int64Converter) FromInt8(v Variant) (int64, error) {
+	i, err := payloadInt8(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
 	}
-	return int64(i)
+	return int64(i), nil
 }
 
-func (c int64Converter) FromInt16(v Variant) int64 {
-	var i int16
-	if len(v.Data) == 2 {
-		i = int16(binary.BigEndian.Uint16(v.Data))
+func (c int64Converter) FromInt16(v Variant) (int64, error) {
+	i, err := payloadInt16(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
 	}
-	return int64(i)
+	return int64(i), nil
 }
 
-func (c int64Converter) FromInt32(v Variant) int64 {
-	return int64(binary.BigEndian.Uint32(v.Data))
+func (c int64Converter) FromInt32(v Variant) (int64, error) {
+	i, err := payloadInt32(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	return int64(i), nil
 }
 
-func (c int64Converter) FromInt64(v Variant) int64 {
-	return int64(binary.BigEndian.Uint64(v.Data))
+func (c int64Converter) FromInt64(v Variant) (int64, error) {
+	i, err := payloadInt64(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	return i, nil
 }
 
-func (c int64Converter) FromUint(v Variant) int64 {
-	var i uint
-	if intSize == 32 {
-		i = uint(binary.BigEndian.Uint32(v.Data))
-		if i > 1<<31-1 {
-			return 0
-		}
-	} else if intSize == 64 {
-		i = uint(binary.BigEndian.Uint64(v.Data))
-		if i > 1<<63-1 {
-			return 0
-		}
+func (c int64Converter) FromUint(v Variant) (int64, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	if i > math.MaxInt64 {
+		return saturate[int64](v, Int64, false)
 	}
-	return int64(i)
+	return int64(i), nil
 }
 
-func (c int64Converter) FromUint8(v Variant) int64 {
-	return int64(v.Data[0])
+func (c int64Converter) FromUint8(v Variant) (int64, error) {
+	i, err := payloadUint8(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	return int64(i), nil
 }
 
-func (c int64Converter) FromUint16(v Variant) int64 {
-	return int64(binary.BigEndian.Uint16(v.Data))
+func (c int64Converter) FromUint16(v Variant) (int64, error) {
+	i, err := payloadUint16(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	return int64(i), nil
 }
 
-func (c int64Converter) FromUint32(v Variant) int64 {
-	return int64(binary.BigEndian.Uint32(v.Data))
+func (c int64Converter) FromUint32(v Variant) (int64, error) {
+	i, err := payloadUint32(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	return int64(i), nil
 }
 
-func (c int64Converter) FromUint64(v Variant) int64 {
-	return int64(binary.BigEndian.Uint64(v.Data))
+func (c int64Converter) FromUint64(v Variant) (int64, error) {
+	i, err := payloadUint64(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	if i > math.MaxInt64 {
+		return saturate[int64](v, Int64, false)
+	}
+	return int64(i), nil
 }
 
-func (c int64Converter) FromFloat32(v Variant) int64 {
-	var f float32
-	if len(v.Data) == 4 {
-		f = math.Float32frombits(binary.BigEndian.Uint32(v.Data))
+func (c int64Converter) FromUintptr(v Variant) (int64, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
 	}
-	return int64(f)
+	if i > math.MaxInt64 {
+		return saturate[int64](v, Int64, false)
+	}
+	return int64(i), nil
 }
 
-func (c int64Converter) FromFloat64(v Variant) int64 {
-	var f float64
-	if len(v.Data) == 8 {
-		f = math.Float64frombits(binary.BigEndian.Uint64(v.Data))
+func (c int64Converter) FromFloat32(v Variant) (int64, error) {
+	f, err := payloadFloat32(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
 	}
-	return int64(f)
+	return floatInt[int64](v, Int64, float64(f))
 }
 
-func (c int64Converter) FromTime(v Variant) int64 {
-	var t time.Time
-	err := t.UnmarshalBinary(v.Data)
+func (c int64Converter) FromFloat64(v Variant) (int64, error) {
+	f, err := payloadFloat64(v)
 	if err != nil {
-		return 0
+		return 0, conversionError(v, Int64, err)
+	}
+	return floatInt[int64](v, Int64, f)
+}
+
+func (c int64Converter) FromComplex64(v Variant) (int64, error) {
+	z, err := payloadComplex64(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	return complexInt[int64](v, Int64, complex128(z))
+}
+
+func (c int64Converter) FromComplex128(v Variant) (int64, error) {
+	z, err := payloadComplex128(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	return complexInt[int64](v, Int64, z)
+}
+
+func (c int64Converter) FromTime(v Variant) (int64, error) {
+	t, err := payloadTime(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	return epochNumber(v, t), nil
+}
+
+func (c int64Converter) FromDuration(v Variant) (int64, error) {
+	d, err := payloadDuration(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	n, err := durationNumber(v, d)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	return int64(n), nil
+}
+
+func (c int64Converter) FromBigInt(v Variant) (int64, error) {
+	b, err := payloadBigInt(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	if !b.IsInt64() {
+		return saturate[int64](v, Int64, b.Sign() < 0)
+	}
+	return b.Int64(), nil
+}
+
+func (c int64Converter) FromBigFloat(v Variant) (int64, error) {
+	b, err := payloadBigFloatInt(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	if !b.IsInt64() {
+		return saturate[int64](v, Int64, b.Sign() < 0)
+	}
+	return b.Int64(), nil
+}
+
+func (c int64Converter) FromDecimal(v Variant) (int64, error) {
+	b, err := payloadDecInt(v)
+	if err != nil {
+		return 0, conversionError(v, Int64, err)
+	}
+	if !b.IsInt64() {
+		return saturate[int64](v, Int64, b.Sign() < 0)
 	}
-	return int64(t.UnixNano())
+	return b.Int64(), nil
 }
 
 func newInt64Converter() IConvertStrategy[int64] {
 	c := &int64Converter{}
-	c.m = map[Kind]func(v Variant) int64{
-		String:  c.FromString,
-		Bool:    c.FromBool,
-		Int:     c.FromInt,
-		Int8:    c.FromInt8,
-		Int16:   c.FromInt16,
-		Int32:   c.FromInt32,
-		Int64:   c.FromInt64,
-		Uint:    c.FromUint,
-		Uint8:   c.FromUint8,
-		Uint16:  c.FromUint16,
-		Uint32:  c.FromUint32,
-		Uint64:  c.FromUint64,
-		Float32: c.FromFloat32,
-		Float64: c.FromFloat64,
-		Time:    c.FromTime,
+	c.m = map[Kind]func(v Variant) (int64, error){
+		String:     c.FromString,
+		Bool:       c.FromBool,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Time:       c.FromTime,
+		Duration:   c.FromDuration,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
 	}
 	return c
 }
@@ -0,0 +1,71 @@
+package variant
+
+import (
+	"fmt"
+	"slices"
+)
+
+// NewList returns a List variant holding the given values, each of them
+// converted with New.
+func NewList(elems ...any) Variant {
+	v := New(elems)
+	if v.Type == Invalid {
+		v.Type = List
+	}
+	return v
+}
+
+// Len returns the number of elements of a List or entries of a Map variant,
+// or 0 for the other kinds.
+func (v Variant) Len() int {
+	if v.obj != nil {
+		return len(v.obj.keys)
+	}
+	return len(v.list)
+}
+
+// Index returns the i-th element of a List variant, or Nil if v is not a List
+// or i is out of range.
+func (v Variant) Index(i int) Variant {
+	if i < 0 || i >= len(v.list) {
+		return Nil
+	}
+	return v.list[i]
+}
+
+// Append appends the given values, each of them converted with New, to the
+// List variant v. An Invalid variant becomes a List, Append panics for the
+// other kinds. Unlike the entries of maps, the elements appended are never
+// seen by the other copies of v.
+func (v *Variant) Append(elems ...any) *Variant {
+	switch v.Type {
+	case Invalid:
+		v.Type = List
+	case List:
+		v.null = false
+	default:
+		panic(fmt.Sprintf("variant: Append on %v variant", v.Type))
+	}
+	// the copies of v share the backing array of list, and end records how far
+	// one of them appended into it: appending from a shorter copy would
+	// overwrite its elements, so the elements are copied first
+	if v.end == nil || *v.end != len(v.list) {
+		v.list = slices.Clip(v.list)
+		v.end = new(int)
+	}
+	for _, elem := range elems {
+		v.list = append(v.list, New(elem))
+	}
+	*v.end = len(v.list)
+	return v
+}
+
+// Range calls fn for each element of a List variant in order, stopping early
+// if fn returns false.
+func (v Variant) Range(fn func(i int, elem Variant) bool) {
+	for i, elem := range v.list {
+		if !fn(i, elem) {
+			return
+		}
+	}
+}
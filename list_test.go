@@ -0,0 +1,95 @@
+package variant
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestVariant_List(t *testing.T) {
+	tt := time.Now()
+	v := New([]any{1, "2", 3.5, tt, []int{4, 5}})
+	assert(v.Type == List)
+	assert(v.Len() == 5)
+	assert(v.Index(0).ToInt() == 1)
+	assert(v.Index(1).ToInt() == 2)
+	assert(v.Index(2).ToString() == "3.5")
+	assert(v.Index(3).ToTime().Equal(tt))
+	assert(v.Index(4).Index(1).ToInt64() == 5)
+	assert(v.Index(5).Type == Invalid)
+	assert(v.Index(-1).Type == Invalid)
+	assert(New(1).Len() == 0)
+
+	sum := 0
+	v.Index(4).Range(func(i int, elem Variant) bool {
+		sum += elem.ToInt()
+		return true
+	})
+	assert(sum == 9)
+
+	n := 0
+	v.Range(func(i int, elem Variant) bool {
+		n++
+		return i < 1
+	})
+	assert(n == 2)
+
+	assert(New([]string{"a", "b"}).Equal([]any{"a", "b"}))
+	assert(New([]Variant{New(1)}).Equal(NewList(1)))
+	assert(New([]byte{1}).Type != List)
+	assert(New([]int(nil)).Type == Invalid)
+}
+
+func TestVariant_Append(t *testing.T) {
+	var v Variant
+	v.Append(1).Append("two", New(3))
+	assert(v.Type == List && v.Len() == 3)
+	assert(v.Index(1).ToString() == "two")
+	assert(v.Index(2).ToInt() == 3)
+
+	l := NewList()
+	assert(l.Type == List && l.Len() == 0)
+	l.Append([]int{1})
+	assert(l.Index(0).Index(0).ToInt() == 1)
+
+	// copies do not see the elements appended to each other
+	a := NewList()
+	a.Append(1, 2, 3)
+	b := a
+	b.Append("b")
+	a.Append("a")
+	assert(a.Len() == 4 && a.Index(3).ToString() == "a", a)
+	assert(b.Len() == 4 && b.Index(3).ToString() == "b", b)
+	b.Append("c")
+	a.Append("d")
+	assert(a.Len() == 5 && a.Index(3).ToString() == "a" && a.Index(4).ToString() == "d", a)
+	assert(b.Len() == 5 && b.Index(3).ToString() == "b" && b.Index(4).ToString() == "c", b)
+
+	defer func() {
+		assert(recover() != nil)
+	}()
+	s := New("x")
+	s.Append(1)
+}
+
+func TestVariant_ListJSON(t *testing.T) {
+	b, err := json.Marshal(New([]any{1, "a", true, nil, []float64{1.5, 2}}))
+	assert(err == nil && string(b) == `[1,"a",true,null,[1.5,2.0]]`, string(b))
+	b, err = json.Marshal(NewList())
+	assert(err == nil && string(b) == `[]`, string(b))
+
+	var v Variant
+	assert(json.Unmarshal([]byte(`[1, "a", [true, "x"], []]`), &v) == nil)
+	assert(v.Type == List && v.Len() == 4)
+	assert(v.Index(0).ToInt() == 1)
+	assert(v.Index(1).ToString() == "a")
+	assert(v.Index(2).Index(0).ToBool())
+	assert(v.Index(3).Type == List && v.Index(3).Len() == 0)
+
+	out, err := json.Marshal(v)
+	assert(err == nil && string(out) == `[1,"a",[true,"x"],[]]`, string(out))
+
+	y, err := v.Index(2).MarshalYAML()
+	assert(err == nil)
+	assert(len(y.([]Variant)) == 2)
+}
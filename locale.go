@@ -0,0 +1,223 @@
+package variant
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// A Locale describes how numbers are written in a language or region. Set as
+// Options.Locale, strings are parsed as numbers written that way, and ToString
+// writes numbers that way.
+type Locale struct {
+	Decimal rune // the decimal separator
+	Group   rune // the separator of groups of integer digits, 0 for none
+
+	// Grouping are the sizes of the groups of integer digits, from the
+	// rightmost one, the last size repeating: {3} writes 1,234,567 and the
+	// Indian {3, 2} writes 12,34,567.
+	Grouping []int
+}
+
+// Locales holds the notation of numbers of some common locales, keyed by
+// their BCP 47 tag. It can be extended, but not concurrently with
+// LookupLocale.
+var Locales = map[string]*Locale{
+	"en":    {Decimal: '.', Group: ',', Grouping: []int{3}},
+	"en-IN": {Decimal: '.', Group: ',', Grouping: []int{3, 2}},
+	"hi":    {Decimal: '.', Group: ',', Grouping: []int{3, 2}},
+	"de":    {Decimal: ',', Group: '.', Grouping: []int{3}},
+	"de-CH": {Decimal: '.', Group: '\u2019', Grouping: []int{3}},
+	"es":    {Decimal: ',', Group: '.', Grouping: []int{3}},
+	"fr":    {Decimal: ',', Group: '\u202f', Grouping: []int{3}},
+	"it":    {Decimal: ',', Group: '.', Grouping: []int{3}},
+	"ja":    {Decimal: '.', Group: ',', Grouping: []int{3}},
+	"nl":    {Decimal: ',', Group: '.', Grouping: []int{3}},
+	"pt":    {Decimal: ',', Group: '.', Grouping: []int{3}},
+	"ru":    {Decimal: ',', Group: '\u00a0', Grouping: []int{3}},
+	"zh":    {Decimal: '.', Group: ',', Grouping: []int{3}},
+}
+
+// LookupLocale returns the locale of Locales with the given tag, or else the
+// one of its language, e.g. "de" for "de-AT". It returns nil if there is none.
+func LookupLocale(tag string) *Locale {
+	tag = strings.ReplaceAll(tag, "_", "-")
+	if l, ok := Locales[tag]; ok {
+		return l
+	}
+	if i := strings.IndexByte(tag, '-'); i > 0 {
+		return Locales[tag[:i]]
+	}
+	return nil
+}
+
+// Format writes s, a number in the notation of Go such as strconv formats,
+// in the notation of l, e.g. "1234567.89" is "1.234.567,89" in German. Other
+// strings are returned unchanged.
+func (l *Locale) Format(s string) string {
+	return string(l.appendFormat(make([]byte, 0, len(s)+len(s)/2), s))
+}
+
+// Normalize writes s, a number in the notation of l, in the one of Go, e.g.
+// "1.234.567,89" is "1234567.89" in German. Groups of digits must follow the
+// grouping of l, but can be left out. It reports ErrSyntax for misplaced
+// separators.
+func (l *Locale) Normalize(s string) (string, error) {
+	b, err := l.appendNormal(nil, []byte(s))
+	return string(b), err
+}
+
+// groupSize returns the size of the group i of integer digits, counted from
+// the right. Sizes that are not positive make the group hold all the digits
+// left.
+func (l *Locale) groupSize(i int) int {
+	n := 3
+	switch {
+	case i < len(l.Grouping):
+		n = l.Grouping[i]
+	case len(l.Grouping) > 0:
+		n = l.Grouping[len(l.Grouping)-1]
+	}
+	if n <= 0 {
+		return math.MaxInt
+	}
+	return n
+}
+
+// isGroup reports whether r separates groups of digits. When l groups them
+// with a space, any kind of space is accepted.
+func (l *Locale) isGroup(r rune) bool {
+	switch r {
+	case l.Group:
+		return r != 0
+	case ' ', '\u00a0', '\u202f':
+		return l.Group == ' ' || l.Group == '\u00a0' || l.Group == '\u202f'
+	}
+	return false
+}
+
+func (l *Locale) appendFormat(b []byte, s string) []byte {
+	i := 0
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		i++
+	}
+	j := i
+	for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+		j++
+	}
+	if j == i {
+		return append(b, s...)
+	}
+	b = append(b, s[:i]...)
+	digits := s[i:j]
+	if l.Group != 0 {
+		// the sizes of the groups, from the right
+		var sizes []int
+		for rest := len(digits); rest > 0; {
+			n := min(l.groupSize(len(sizes)), rest)
+			sizes = append(sizes, n)
+			rest -= n
+		}
+		for k := len(sizes) - 1; k > 0; k-- {
+			b = append(b, digits[:sizes[k]]...)
+			b = utf8.AppendRune(b, l.Group)
+			digits = digits[sizes[k]:]
+		}
+	}
+	b = append(b, digits...)
+	if j < len(s) && s[j] == '.' {
+		b = utf8.AppendRune(b, l.Decimal)
+		j++
+	}
+	return append(b, s[j:]...)
+}
+
+func (l *Locale) appendNormal(b []byte, s []byte) ([]byte, error) {
+	s = bytes.TrimSpace(s)
+	i := 0
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		i++
+	}
+	// the integer part ends at the decimal separator or the exponent
+	j, grouped := i, false
+	for j < len(s) {
+		r, size := utf8.DecodeRune(s[j:])
+		if r == l.Decimal || r == 'e' || r == 'E' {
+			break
+		}
+		grouped = grouped || l.isGroup(r)
+		j += size
+	}
+	if grouped {
+		if err := l.checkGroups(s[i:j]); err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, s[:i]...)
+	for k := i; k < j; {
+		r, size := utf8.DecodeRune(s[k:])
+		if !l.isGroup(r) {
+			b = append(b, s[k:k+size]...)
+		}
+		k += size
+	}
+	if r, size := utf8.DecodeRune(s[j:]); j < len(s) && r == l.Decimal {
+		b = append(b, '.')
+		j += size
+	}
+	return append(b, s[j:]...), nil
+}
+
+// checkGroups reports ErrSyntax unless s is made of decimal digits in groups
+// following the grouping of l.
+func (l *Locale) checkGroups(s []byte) error {
+	n, group := 0, 0 // the digits of the current group, its index
+	for len(s) > 0 {
+		r, size := utf8.DecodeLastRune(s)
+		s = s[:len(s)-size]
+		switch {
+		case r >= '0' && r <= '9':
+			n++
+		case l.isGroup(r) && n == l.groupSize(group):
+			n = 0
+			group++
+		default:
+			return ErrSyntax
+		}
+	}
+	if n == 0 || n > l.groupSize(group) {
+		return ErrSyntax
+	}
+	return nil
+}
+
+// numeral returns the payload of v, a String variant holding a number, in
+// the notation of Go, converted from the one of the locale of v if any.
+func (v Variant) numeral() ([]byte, error) {
+	l := v.options().Locale
+	if l == nil {
+		return v.Data, nil
+	}
+	return l.appendNormal(make([]byte, 0, len(v.Data)), v.Data)
+}
+
+// localize writes s, a number in the notation of Go, in the one of the locale
+// of v if any.
+func (v Variant) localize(s string) string {
+	if l := v.options().Locale; l != nil {
+		return l.Format(s)
+	}
+	return s
+}
+
+// canonical returns v converted without the locale of its options, for the
+// encodings whose numbers are in the notation of Go.
+func (v Variant) canonical() Variant {
+	if o := v.options(); o.Locale != nil {
+		c := *o
+		c.Locale = nil
+		return v.With(NewConverter(c))
+	}
+	return v
+}
@@ -0,0 +1,113 @@
+package variant
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestLocale_Format(t *testing.T) {
+	targets := []struct {
+		tag, s, want string
+	}{
+		{"en", "1234567.89", "1,234,567.89"},
+		{"en", "-1234", "-1,234"},
+		{"en", "123", "123"},
+		{"en", "0.5", "0.5"},
+		{"de", "1234567.89", "1.234.567,89"},
+		{"de", "-1234567", "-1.234.567"},
+		{"en-IN", "1234567", "12,34,567"},
+		{"en-IN", "123456789.5", "12,34,56,789.5"},
+		{"fr", "1234.5", "1\u202f234,5"},
+		{"de-CH", "1234567.5", "1’234’567.5"},
+		{"de", "1e+21", "1e+21"},
+		{"de", "1234.5e+21", "1.234,5e+21"},
+		{"de", "+Inf", "+Inf"},
+		{"de", "NaN", "NaN"},
+	}
+	for _, tt := range targets {
+		got := LookupLocale(tt.tag).Format(tt.s)
+		assert(got == tt.want, tt.tag, tt.s, got)
+	}
+	l := &Locale{Decimal: ',', Grouping: []int{3}}
+	assert(l.Format("1234.5") == "1234,5", l.Format("1234.5"))
+}
+
+func TestLocale_Normalize(t *testing.T) {
+	targets := []struct {
+		tag, s, want string
+		err          error
+	}{
+		{"de", "1.234.567,89", "1234567.89", nil},
+		{"de", " -1.234 ", "-1234", nil},
+		{"de", "1234567,89", "1234567.89", nil},
+		{"de", "1,5e3", "1.5e3", nil},
+		{"en", "1,234,567.89", "1234567.89", nil},
+		{"en-IN", "12,34,567", "1234567", nil},
+		{"en-IN", "1,234,567", "", ErrSyntax},
+		{"en", "12,34,567", "", ErrSyntax},
+		{"de", "1.5", "", ErrSyntax},
+		{"de", "1.23.456", "", ErrSyntax},
+		{"de", ".123", "", ErrSyntax},
+		{"de", "1234.567", "", ErrSyntax},
+		{"de", "1.234.", "", ErrSyntax},
+		{"fr", "1 234,5", "1234.5", nil},
+		{"fr", "1\u00a0234,5", "1234.5", nil},
+		{"ru", "1\u202f234", "1234", nil},
+	}
+	for _, tt := range targets {
+		got, err := LookupLocale(tt.tag).Normalize(tt.s)
+		assert(errors.Is(err, tt.err) && (err != nil || got == tt.want), tt.tag, tt.s, got, err)
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	assert(LookupLocale("de-AT") == Locales["de"])
+	assert(LookupLocale("pt_BR") == Locales["pt"])
+	assert(LookupLocale("en-IN") == Locales["en-IN"])
+	assert(LookupLocale("xx") == nil)
+}
+
+func TestPolicy_Locale(t *testing.T) {
+	de := NewConverter(Options{Locale: LookupLocale("de")})
+	assert(de.ToFloat64(New("1.234.567,89")) == 1234567.89, de.ToFloat64(New("1.234.567,89")))
+	assert(de.ToFloat32(New("-0,5")) == -0.5)
+	assert(de.ToInt(New("1.234")) == 1234)
+	assert(de.ToInt16(New("-1.234")) == -1234)
+	assert(de.ToUint64(New("18.446.744.073.709.551.615")) == 18446744073709551615)
+	assert(de.ToDecimal(New("-1.234,50")).String() == "-1234.50")
+	assert(de.ToBigInt(New("1.000.000.000.000.000.000.000")).String() == "1000000000000000000000")
+	assert(de.ToBigFloat(New("2,5")).String() == "2.5")
+	assert(New("2,5").With(de).Round(RoundHalfUp).ToInt() == 3)
+	_, err := de.TryInt(New("1.23"))
+	assert(errors.Is(err, ErrSyntax), err)
+
+	assert(de.ToString(New(1234567.89)) == "1.234.567,89", de.ToString(New(1234567.89)))
+	assert(de.ToString(New(float32(-1234.5))) == "-1.234,5")
+	assert(de.ToString(New(int64(-1234567))) == "-1.234.567")
+	assert(de.ToString(New(uint8(200))) == "200")
+	assert(de.ToString(New(NewDec(123456, 2))) == "1.234,56")
+	assert(de.ToString(New(big.NewInt(1234567))) == "1.234.567")
+	assert(de.ToString(New("1234")) == "1234")
+
+	in := NewConverter(Options{Locale: LookupLocale("en-IN")})
+	assert(in.ToInt64(New("12,34,567")) == 1234567)
+	assert(in.ToString(New(NewDec(123456789, 2))) == "12,34,567.89")
+
+	_, err = New("1,234").TryInt()
+	assert(errors.Is(err, ErrSyntax), err)
+	assert(New(1234567.5).ToString() == "1234567.5")
+}
+
+func TestPolicy_Locale_encodings(t *testing.T) {
+	de := NewConverter(Options{Locale: LookupLocale("de")})
+	data, err := json.Marshal(New(big.NewInt(1234567)).With(de))
+	assert(err == nil && string(data) == "1234567", string(data), err)
+	data, err = json.Marshal(New(1234.5).With(de))
+	assert(err == nil && string(data) == "1234.5", string(data), err)
+	val, err := New(NewDec(123456, 2)).With(de).Value()
+	assert(err == nil && val == "1234.56", val, err)
+	data, err = json.Marshal(Typed{New(NewDec(123456, 2)).With(de)})
+	assert(err == nil && string(data) == `{"t":"decimal","v":"1234.56"}`, string(data), err)
+}
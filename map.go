@@ -0,0 +1,135 @@
+package variant
+
+import (
+	"fmt"
+	"slices"
+)
+
+// object holds the entries of a Map variant in insertion order.
+type object struct {
+	keys []string
+	m    map[string]Variant
+}
+
+func newObject(size int) *object {
+	return &object{
+		keys: make([]string, 0, size),
+		m:    make(map[string]Variant, size),
+	}
+}
+
+func (o *object) set(key string, value Variant) {
+	if _, ok := o.m[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.m[key] = value
+}
+
+func (o *object) delete(key string) {
+	if _, ok := o.m[key]; ok {
+		delete(o.m, key)
+		o.keys = slices.DeleteFunc(o.keys, func(k string) bool { return k == key })
+	}
+}
+
+// entries returns the entries of o for printing.
+func (o *object) entries() map[string]Variant {
+	if o == nil {
+		return nil
+	}
+	return o.m
+}
+
+// sortedKeys returns the keys of m in increasing order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// NewMap returns an empty Map variant.
+func NewMap() Variant {
+	return New(map[string]Variant{})
+}
+
+// Get returns the value of key in a Map variant, or Nil if v is not a Map or
+// has no such key.
+func (v Variant) Get(key string) Variant {
+	if v.obj == nil {
+		return Nil
+	}
+	if value, ok := v.obj.m[key]; ok {
+		return value
+	}
+	return Nil
+}
+
+// Set sets key to value, converted with New, in the Map variant v. A new key
+// is added after the existing ones. An Invalid variant becomes a Map, Set
+// panics for the other kinds. Like Go maps, the copies of a Map variant share
+// their entries, Set also panics if value holds v, which would make v contain
+// itself.
+func (v *Variant) Set(key string, value any) *Variant {
+	switch v.Type {
+	case Invalid:
+		v.Type = Map
+		v.obj = newObject(0)
+	case Map:
+		v.null = false
+		if v.obj == nil {
+			v.obj = newObject(0)
+		}
+	default:
+		panic(fmt.Sprintf("variant: Set on %v variant", v.Type))
+	}
+	elem := New(value)
+	if elem.holds(v.obj, map[*object]bool{}) {
+		panic("variant: Set of a value holding the Map variant itself")
+	}
+	v.obj.set(key, elem)
+	return v
+}
+
+// holds reports whether the tree of v reaches the entries o. seen records the
+// maps already searched, which copies of a map can share.
+func (v Variant) holds(o *object, seen map[*object]bool) bool {
+	if v.obj != nil {
+		if v.obj == o {
+			return true
+		}
+		if seen[v.obj] {
+			return false
+		}
+		seen[v.obj] = true
+		for _, elem := range v.obj.m {
+			if elem.holds(o, seen) {
+				return true
+			}
+		}
+	}
+	for _, elem := range v.list {
+		if elem.holds(o, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns the keys of a Map variant in insertion order. Maps built from
+// Go maps are ordered by key, maps decoded from JSON keep the document order.
+func (v Variant) Keys() []string {
+	if v.obj == nil {
+		return nil
+	}
+	return slices.Clone(v.obj.keys)
+}
+
+// Delete removes key from a Map variant.
+func (v *Variant) Delete(key string) {
+	if v.obj != nil {
+		v.obj.delete(key)
+	}
+}
@@ -0,0 +1,104 @@
+package variant
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestVariant_Map(t *testing.T) {
+	v := New(map[string]any{"b": 2, "a": "1", "c": []int{3}})
+	assert(v.Type == Map && v.Len() == 3)
+	assert(slices.Equal(v.Keys(), []string{"a", "b", "c"}))
+	assert(v.Get("a").ToInt() == 1)
+	assert(v.Get("b").ToString() == "2")
+	assert(v.Get("c").Index(0).ToInt() == 3)
+	assert(v.Get("d").Type == Invalid)
+	assert(New(1).Get("a").Type == Invalid)
+
+	v.Set("d", map[string]int{"x": 4}).Set("a", true)
+	assert(slices.Equal(v.Keys(), []string{"a", "b", "c", "d"}))
+	assert(v.Get("a").ToBool())
+	assert(v.Get("d").Get("x").ToInt() == 4)
+
+	v.Delete("b")
+	v.Delete("missing")
+	assert(slices.Equal(v.Keys(), []string{"a", "c", "d"}))
+	assert(v.Len() == 3)
+
+	assert(New(map[int]string{1: "a"}).Get("1").ToString() == "a")
+	assert(New(map[string]Variant{"k": New(1)}).Equal(map[string]any{"k": 1}))
+	assert(!New(map[string]any{"k": 1}).Equal(map[string]any{"k": 2}))
+	assert(New(map[string]any(nil)).Type == Invalid)
+
+	// a cycle ends with an Invalid variant
+	cyclic := map[string]any{"k": 1}
+	cyclic["self"] = cyclic
+	cyclic["list"] = []any{2, cyclic}
+	cyclic["ptr"] = &cyclic
+	v = New(cyclic)
+	assert(v.Get("k").ToInt() == 1 && v.Get("self").Type == Invalid, v)
+	assert(v.Get("list").Index(0).ToInt() == 2 && v.Get("list").Index(1).Type == Invalid, v)
+	assert(v.Get("ptr").Type == Invalid, v)
+	shared := []any{3}
+	v = New(map[string]any{"x": shared, "y": []any{shared}})
+	assert(v.Get("x").Index(0).ToInt() == 3 && v.Get("y").Index(0).Index(0).ToInt() == 3, v)
+
+	var s Variant
+	s.Set("k", 1)
+	assert(s.Type == Map && s.Get("k").ToInt() == 1)
+	m := NewMap()
+	assert(m.Type == Map && m.Len() == 0)
+
+	defer func() {
+		assert(recover() != nil)
+	}()
+	l := NewList()
+	l.Set("k", 1)
+}
+
+func TestVariant_SetCycle(t *testing.T) {
+	panics := func(f func()) (ok bool) {
+		defer func() { ok = recover() != nil }()
+		f()
+		return false
+	}
+	m := NewMap()
+	m.Set("a", NewMap())
+	assert(panics(func() { m.Set("self", m) }))
+	inner := m.Get("a")
+	assert(panics(func() { inner.Set("back", m) }))
+	assert(panics(func() { inner.Set("list", NewList(1, m)) }))
+
+	// copies of another map are not cycles
+	other := NewMap()
+	other.Set("k", 1)
+	m.Set("x", other).Set("y", NewList(other, other))
+	inner.Set("x", m.Get("x"))
+	data, err := m.MarshalJSON()
+	assert(err == nil && string(data) == `{"a":{"x":{"k":1}},"x":{"k":1},"y":[{"k":1},{"k":1}]}`, string(data), err)
+}
+
+func TestVariant_MapJSON(t *testing.T) {
+	data := []byte(`{"name": "lili", "tags": ["a", "b"], "address": {"city": "x", "zip": "100"}, "age": "36"}`)
+	var v Variant
+	assert(json.Unmarshal(data, &v) == nil)
+	assert(v.Type == Map)
+	assert(slices.Equal(v.Keys(), []string{"name", "tags", "address", "age"}))
+	assert(v.Get("name").ToString() == "lili")
+	assert(v.Get("tags").Index(1).ToString() == "b")
+	assert(v.Get("address").Get("zip").ToInt() == 100)
+	assert(v.Get("age").ToInt() == 36)
+
+	out, err := json.Marshal(v)
+	assert(err == nil)
+	assert(string(out) == `{"name":"lili","tags":["a","b"],"address":{"city":"x","zip":"100"},"age":"36"}`, string(out))
+
+	out, err = json.Marshal(NewMap())
+	assert(err == nil && string(out) == `{}`, string(out))
+
+	assert(json.Unmarshal([]byte(`{"a": }`), &v) != nil)
+
+	y, err := v.Get("address").MarshalYAML()
+	assert(err == nil && len(y.(map[string]Variant)) == 2)
+}
@@ -0,0 +1,554 @@
+package variant
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// MessagePack has fewer types than there are kinds, MarshalMsgpack maps each
+// kind to the tightest type able to hold its value:
+//
+//	Invalid, typed nulls                  nil
+//	Bool                                  bool
+//	Int, Int8, Int16, Int32, Int64        positive or negative fixint, int 8 to int 64
+//	Duration                              as an int, in nanoseconds
+//	Uint, Uint8, Uint16, Uint32, Uint64   positive fixint, uint 8 to uint 64
+//	Uintptr                               as a uint
+//	Float32, Float64                      float 32, float 64
+//	String                                fixstr, str 8 to str 32
+//	Bytes                                 bin 8 to bin 32
+//	Time                                  timestamp extension (-1), 32, 64 or 96 bits
+//	Complex, BigInt, BigFloat, Decimal    str, their exact text form
+//	List                                  fixarray, array 16 or array 32
+//	Map                                   fixmap, map 16 or map 32, keeping the order
+//
+// Decoding gives an Int64 for fixints and signed ints, an Uint64 for unsigned
+// ints, a Float32 or Float64, a String, a Bytes, a Time in UTC, a List or a
+// Map. Map keys that are not strings are converted with ToString.
+
+const (
+	msgpackTimestamp = -1
+
+	// msgpackChunk is the size above which the streaming encoder writes its
+	// buffer out, and the decoder stops trusting declared lengths to allocate.
+	msgpackChunk = 64 << 10
+)
+
+// MarshalMsgpack returns the MessagePack encoding of v.
+func (v Variant) MarshalMsgpack() ([]byte, error) {
+	var e MsgpackEncoder
+	if err := e.encode(v); err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
+
+// UnmarshalMsgpack decodes the MessagePack value in data, which must hold
+// exactly one value, into v. A nil value keeps the declared kind of v.
+func (v *Variant) UnmarshalMsgpack(data []byte) error {
+	d := NewMsgpackDecoder(bytes.NewReader(data))
+	if err := d.Decode(v); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if _, err := d.r.ReadByte(); err != io.EOF {
+		return errors.New("variant: trailing data after msgpack value")
+	}
+	return nil
+}
+
+// A MsgpackEncoder writes variants as MessagePack values to an output stream.
+// Large strings, byte slices, lists and maps are written out as they are
+// encoded rather than buffered whole.
+type MsgpackEncoder struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewMsgpackEncoder returns an encoder writing to w.
+func NewMsgpackEncoder(w io.Writer) *MsgpackEncoder {
+	return &MsgpackEncoder{w: w}
+}
+
+// Encode writes the MessagePack encoding of v to the stream. When it fails
+// part of v may have been written already.
+func (e *MsgpackEncoder) Encode(v Variant) error {
+	err := e.encode(v)
+	if err != nil {
+		e.buf = e.buf[:0]
+		return err
+	}
+	return e.flush()
+}
+
+// flush writes the buffer out, it does nothing when the encoder has no writer,
+// e.g. in MarshalMsgpack.
+func (e *MsgpackEncoder) flush() error {
+	if e.w == nil || len(e.buf) == 0 {
+		return nil
+	}
+	_, err := e.w.Write(e.buf)
+	e.buf = e.buf[:0]
+	return err
+}
+
+// write appends p to the buffer, or writes it out directly when it is large.
+func (e *MsgpackEncoder) write(p []byte) error {
+	if e.w == nil || len(p) < msgpackChunk {
+		e.buf = append(e.buf, p...)
+		return nil
+	}
+	if err := e.flush(); err != nil {
+		return err
+	}
+	_, err := e.w.Write(p)
+	return err
+}
+
+func (e *MsgpackEncoder) encode(v Variant) error {
+	if v.null || v.Type == Invalid {
+		e.buf = append(e.buf, 0xc0)
+		return nil
+	}
+	switch v.Type {
+	case Bool:
+		b, err := v.TryBool()
+		if err != nil {
+			return err
+		}
+		if b {
+			e.buf = append(e.buf, 0xc3)
+		} else {
+			e.buf = append(e.buf, 0xc2)
+		}
+		return nil
+	case Int, Int8, Int16, Int32, Int64, Duration:
+		i, err := binaryInt(v)
+		if err != nil {
+			return conversionError(v, Int64, err)
+		}
+		e.buf = appendMsgpackInt(e.buf, i)
+		return nil
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		u, err := binaryUint(v)
+		if err != nil {
+			return conversionError(v, Uint64, err)
+		}
+		e.buf = appendMsgpackUint(e.buf, u)
+		return nil
+	case Float32:
+		f, err := payloadFloat32(v)
+		if err != nil {
+			return conversionError(v, Float32, err)
+		}
+		e.buf = binary.BigEndian.AppendUint32(append(e.buf, 0xca), math.Float32bits(f))
+		return nil
+	case Float64:
+		f, err := payloadFloat64(v)
+		if err != nil {
+			return conversionError(v, Float64, err)
+		}
+		e.buf = binary.BigEndian.AppendUint64(append(e.buf, 0xcb), math.Float64bits(f))
+		return nil
+	case String:
+		e.buf = appendMsgpackStr(e.buf, len(v.Data))
+		return e.write(v.Data)
+	case Complex64, Complex128, BigInt, BigFloat, Decimal:
+		s, err := v.canonical().TryString()
+		if err != nil {
+			return err
+		}
+		e.buf = appendMsgpackStr(e.buf, len(s))
+		e.buf = append(e.buf, s...)
+		return nil
+	case Bytes:
+		e.buf = appendMsgpackBin(e.buf, len(v.Data))
+		return e.write(v.Data)
+	case Time:
+		t, err := payloadTime(v)
+		if err != nil {
+			return conversionError(v, Time, err)
+		}
+		e.buf = appendMsgpackTime(e.buf, t)
+		return nil
+	case List:
+		e.buf = appendMsgpackLen(e.buf, len(v.list), 0x90, 0xdc, 0xdd)
+		for _, elem := range v.list {
+			if err := e.encodeElem(elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	case Map:
+		keys := v.Keys()
+		e.buf = appendMsgpackLen(e.buf, len(keys), 0x80, 0xde, 0xdf)
+		for _, key := range keys {
+			e.buf = appendMsgpackStr(e.buf, len(key))
+			e.buf = append(e.buf, key...)
+			if err := e.encodeElem(v.obj.m[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return conversionError(v, Bytes, ErrUnsupported)
+}
+
+// encodeElem encodes an element of a list or map, writing the buffer out once
+// it grows large.
+func (e *MsgpackEncoder) encodeElem(v Variant) error {
+	if err := e.encode(v); err != nil {
+		return err
+	}
+	if len(e.buf) >= msgpackChunk {
+		return e.flush()
+	}
+	return nil
+}
+
+func appendMsgpackInt(b []byte, i int64) []byte {
+	switch {
+	case i >= -32 && i <= math.MaxInt8:
+		return append(b, byte(i))
+	case i >= math.MinInt8 && i <= math.MaxInt8:
+		return append(b, 0xd0, byte(i))
+	case i >= math.MinInt16 && i <= math.MaxInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(i))
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(i))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(i))
+}
+
+func appendMsgpackUint(b []byte, u uint64) []byte {
+	switch {
+	case u <= math.MaxInt8:
+		return append(b, byte(u))
+	case u <= math.MaxUint8:
+		return append(b, 0xcc, byte(u))
+	case u <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(u))
+	case u <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(u))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xcf), u)
+}
+
+// appendMsgpackStr appends the header of a str of n bytes.
+func appendMsgpackStr(b []byte, n int) []byte {
+	switch {
+	case n < 32:
+		return append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+}
+
+// appendMsgpackBin appends the header of a bin of n bytes.
+func appendMsgpackBin(b []byte, n int) []byte {
+	switch {
+	case n <= math.MaxUint8:
+		return append(b, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xc5), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, 0xc6), uint32(n))
+}
+
+// appendMsgpackLen appends the header of an array or a map of n elements, fix
+// being the code of its fixed form.
+func appendMsgpackLen(b []byte, n int, fix, c16, c32 byte) []byte {
+	switch {
+	case n < 16:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, c16), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, c32), uint32(n))
+}
+
+// appendMsgpackTime appends t as a timestamp extension, in the 32 bits form
+// for whole seconds since 1970 up to 2106, the 64 bits form for up to 2514
+// and the 96 bits form otherwise.
+func appendMsgpackTime(b []byte, t time.Time) []byte {
+	sec, nsec := t.Unix(), uint64(t.Nanosecond())
+	if sec>>34 == 0 {
+		data := nsec<<34 | uint64(sec)
+		if data>>32 == 0 {
+			b = append(b, 0xd6, byte(msgpackTimestamp&0xff))
+			return binary.BigEndian.AppendUint32(b, uint32(data))
+		}
+		b = append(b, 0xd7, byte(msgpackTimestamp&0xff))
+		return binary.BigEndian.AppendUint64(b, data)
+	}
+	b = append(b, 0xc7, 12, byte(msgpackTimestamp&0xff))
+	b = binary.BigEndian.AppendUint32(b, uint32(nsec))
+	return binary.BigEndian.AppendUint64(b, uint64(sec))
+}
+
+// A MsgpackDecoder reads MessagePack values from an input stream.
+type MsgpackDecoder struct {
+	r     msgpackReader
+	depth int // the nesting of the value being decoded
+}
+
+type msgpackReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// NewMsgpackDecoder returns a decoder reading from r. It buffers r unless r
+// already implements io.ByteReader, and may then read past the values it
+// decodes.
+func NewMsgpackDecoder(r io.Reader) *MsgpackDecoder {
+	br, ok := r.(msgpackReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &MsgpackDecoder{r: br}
+}
+
+// Decode reads the next MessagePack value from the stream into v. A nil
+// value keeps the declared kind of v. At the end of the stream Decode
+// returns io.EOF, and io.ErrUnexpectedEOF when it ends within a value.
+func (d *MsgpackDecoder) Decode(v *Variant) error {
+	c, err := d.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	r, err := d.value(c)
+	if err != nil {
+		return err
+	}
+	if c == 0xc0 {
+		r = NewNull(v.Type)
+	}
+	*v = r
+	return nil
+}
+
+func (d *MsgpackDecoder) readByte() (byte, error) {
+	c, err := d.r.ReadByte()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return c, err
+}
+
+// uint reads a big-endian unsigned integer of n bytes.
+func (d *MsgpackDecoder) uint(n int) (uint64, error) {
+	var u uint64
+	for range n {
+		c, err := d.readByte()
+		if err != nil {
+			return 0, err
+		}
+		u = u<<8 | uint64(c)
+	}
+	return u, nil
+}
+
+// bytes reads n bytes. Beyond msgpackChunk it grows its buffer as the data
+// arrives, so that a bogus length cannot make it allocate gigabytes.
+func (d *MsgpackDecoder) bytes(n uint64) ([]byte, error) {
+	if n <= msgpackChunk {
+		b := make([]byte, n)
+		if _, err := io.ReadFull(d.r, b); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		return b, nil
+	}
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, d.r, int64(n)); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// value decodes the value whose first byte is c.
+func (d *MsgpackDecoder) value(c byte) (Variant, error) {
+	switch {
+	case c <= 0x7f:
+		return New(int64(c)), nil
+	case c >= 0xe0:
+		return New(int64(int8(c))), nil
+	case c <= 0x8f:
+		return d.object(uint64(c & 0x0f))
+	case c <= 0x9f:
+		return d.array(uint64(c & 0x0f))
+	case c <= 0xbf:
+		return d.str(uint64(c & 0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		return NewNull(Invalid), nil
+	case 0xc2:
+		return New(false), nil
+	case 0xc3:
+		return New(true), nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.uint(1 << (c - 0xc4))
+		if err != nil {
+			return Nil, err
+		}
+		b, err := d.bytes(n)
+		if err != nil {
+			return Nil, err
+		}
+		return New(b), nil
+	case 0xc7, 0xc8, 0xc9:
+		n, err := d.uint(1 << (c - 0xc7))
+		if err != nil {
+			return Nil, err
+		}
+		return d.ext(n)
+	case 0xca:
+		u, err := d.uint(4)
+		return New(math.Float32frombits(uint32(u))), err
+	case 0xcb:
+		u, err := d.uint(8)
+		return New(math.Float64frombits(u)), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		u, err := d.uint(1 << (c - 0xcc))
+		return New(u), err
+	case 0xd0:
+		u, err := d.uint(1)
+		return New(int64(int8(u))), err
+	case 0xd1:
+		u, err := d.uint(2)
+		return New(int64(int16(u))), err
+	case 0xd2:
+		u, err := d.uint(4)
+		return New(int64(int32(u))), err
+	case 0xd3:
+		u, err := d.uint(8)
+		return New(int64(u)), err
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.ext(1 << (c - 0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.uint(1 << (c - 0xd9))
+		if err != nil {
+			return Nil, err
+		}
+		return d.str(n)
+	case 0xdc, 0xdd:
+		n, err := d.uint(2 << (c - 0xdc))
+		if err != nil {
+			return Nil, err
+		}
+		return d.array(n)
+	case 0xde, 0xdf:
+		n, err := d.uint(2 << (c - 0xde))
+		if err != nil {
+			return Nil, err
+		}
+		return d.object(n)
+	}
+	return Nil, fmt.Errorf("variant: invalid msgpack code 0x%02x", c)
+}
+
+func (d *MsgpackDecoder) str(n uint64) (Variant, error) {
+	b, err := d.bytes(n)
+	if err != nil {
+		return Nil, err
+	}
+	return Variant{Type: String, Data: b, layout: time.DateTime}, nil
+}
+
+func (d *MsgpackDecoder) array(n uint64) (Variant, error) {
+	v := NewList()
+	v.list = make([]Variant, 0, min(n, 1024))
+	for range n {
+		elem, err := d.next()
+		if err != nil {
+			return Nil, err
+		}
+		v.list = append(v.list, elem)
+	}
+	return v, nil
+}
+
+func (d *MsgpackDecoder) object(n uint64) (Variant, error) {
+	v := NewMap()
+	for range n {
+		key, err := d.next()
+		if err != nil {
+			return Nil, err
+		}
+		k, err := key.TryString()
+		if err != nil {
+			return Nil, err
+		}
+		elem, err := d.next()
+		if err != nil {
+			return Nil, err
+		}
+		v.obj.set(k, elem)
+	}
+	return v, nil
+}
+
+// next decodes a value nested in an array or a map, up to maxDepth levels.
+func (d *MsgpackDecoder) next() (Variant, error) {
+	c, err := d.readByte()
+	if err != nil {
+		return Nil, err
+	}
+	if d.depth++; d.depth > maxDepth {
+		return Nil, errDepth
+	}
+	v, err := d.value(c)
+	d.depth--
+	return v, err
+}
+
+// ext decodes an extension of n bytes, only timestamps are supported.
+func (d *MsgpackDecoder) ext(n uint64) (Variant, error) {
+	typ, err := d.readByte()
+	if err != nil {
+		return Nil, err
+	}
+	data, err := d.bytes(n)
+	if err != nil {
+		return Nil, err
+	}
+	if int8(typ) != msgpackTimestamp {
+		return Nil, fmt.Errorf("variant: msgpack extension %d: %w", int8(typ), ErrUnsupported)
+	}
+	var sec, nsec int64
+	switch n {
+	case 4:
+		sec = int64(binary.BigEndian.Uint32(data))
+	case 8:
+		u := binary.BigEndian.Uint64(data)
+		sec, nsec = int64(u&(1<<34-1)), int64(u>>34)
+	case 12:
+		nsec = int64(binary.BigEndian.Uint32(data))
+		sec = int64(binary.BigEndian.Uint64(data[4:]))
+	default:
+		return Nil, fmt.Errorf("variant: invalid msgpack timestamp of %d bytes", n)
+	}
+	if nsec > 999999999 {
+		return Nil, fmt.Errorf("variant: invalid msgpack timestamp nanoseconds %d", nsec)
+	}
+	return New(time.Unix(sec, nsec).UTC()), nil
+}
@@ -0,0 +1,171 @@
+package variant
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVariant_MarshalMsgpack(t *testing.T) {
+	tests := []struct {
+		v    Variant
+		data []byte
+		want Variant
+	}{
+		{Nil, []byte{0xc0}, NewNull(Invalid)},
+		{NewNull(Int), []byte{0xc0}, NewNull(Invalid)},
+		{New(true), []byte{0xc3}, New(true)},
+		{New(int8(5)), []byte{0x05}, New(int64(5))},
+		{New(-32), []byte{0xe0}, New(int64(-32))},
+		{New(-33), []byte{0xd0, 0xdf}, New(int64(-33))},
+		{New(int16(300)), []byte{0xd1, 0x01, 0x2c}, New(int64(300))},
+		{New(int32(-70000)), []byte{0xd2, 0xff, 0xfe, 0xee, 0x90}, New(int64(-70000))},
+		{New(int64(math.MinInt64)), []byte{0xd3, 0x80, 0, 0, 0, 0, 0, 0, 0}, New(int64(math.MinInt64))},
+		{New(time.Second), []byte{0xd2, 0x3b, 0x9a, 0xca, 0x00}, New(int64(time.Second))},
+		{New(uint(127)), []byte{0x7f}, New(int64(127))},
+		{New(uint8(200)), []byte{0xcc, 0xc8}, New(uint64(200))},
+		{New(uint64(math.MaxUint64)), []byte{0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, New(uint64(math.MaxUint64))},
+		{New(float32(1.5)), []byte{0xca, 0x3f, 0xc0, 0, 0}, New(float32(1.5))},
+		{New(1.5), []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}, New(1.5)},
+		{New("abc"), []byte{0xa3, 'a', 'b', 'c'}, New("abc")},
+		{New([]byte{1, 2}), []byte{0xc4, 2, 1, 2}, New([]byte{1, 2})},
+		{New(NewDec(125, 2)), []byte{0xa4, '1', '.', '2', '5'}, New("1.25")},
+		{NewList(1, "a"), []byte{0x92, 0x01, 0xa1, 'a'}, NewList(int64(1), "a")},
+		{New(map[string]any{"b": 1, "a": nil}), []byte{0x82, 0xa1, 'a', 0xc0, 0xa1, 'b', 0x01}, New(map[string]any{"a": nil, "b": int64(1)})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v.Type.String(), func(t *testing.T) {
+			data, err := tt.v.MarshalMsgpack()
+			assert(err == nil, tt.v, err)
+			assert(bytes.Equal(data, tt.data), tt.v, data)
+			var got Variant
+			assert(got.UnmarshalMsgpack(data) == nil, data)
+			assert(equal(got, tt.want), got, tt.want)
+		})
+	}
+
+	// the order of map entries is kept
+	ordered := NewMap()
+	ordered.Set("z", 1).Set("a", 2)
+	data, _ := ordered.MarshalMsgpack()
+	var m Variant
+	assert(m.UnmarshalMsgpack(data) == nil)
+	assert(strings.Join(m.Keys(), ",") == "z,a", m.Keys())
+
+	// nil keeps the declared kind of the target
+	v := NewNull(String)
+	assert(v.UnmarshalMsgpack([]byte{0xc0}) == nil)
+	assert(v.Type == String && v.IsNull(), v)
+}
+
+func TestVariant_MsgpackLengths(t *testing.T) {
+	for _, n := range []int{31, 32, 255, 256, 65535, 65536} {
+		s := strings.Repeat("x", n)
+		data, err := New(s).MarshalMsgpack()
+		assert(err == nil, err)
+		var v Variant
+		assert(v.UnmarshalMsgpack(data) == nil, n)
+		assert(v.ToString() == s, n)
+
+		data, err = New([]byte(s)).MarshalMsgpack()
+		assert(err == nil, err)
+		assert(v.UnmarshalMsgpack(data) == nil, n)
+		assert(v.Type == Bytes && len(v.Data) == n, n)
+	}
+	for _, n := range []int{15, 16, 65536} {
+		l := NewList()
+		for i := range n {
+			l.Append(i % 100)
+		}
+		data, err := l.MarshalMsgpack()
+		assert(err == nil, err)
+		var v Variant
+		assert(v.UnmarshalMsgpack(data) == nil, n)
+		assert(v.Len() == n && v.Index(n-1).ToInt() == (n-1)%100, n)
+	}
+}
+
+func TestVariant_MsgpackTime(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		code byte
+		size int
+	}{
+		{time.Unix(1700000000, 0), 0xd6, 6},
+		{time.Unix(1700000000, 5), 0xd7, 10},
+		{time.Unix(-1, 0), 0xc7, 15},
+		{time.Date(3000, 1, 1, 0, 0, 0, 1, time.UTC), 0xc7, 15},
+	}
+	for _, tt := range tests {
+		data, err := New(tt.t).MarshalMsgpack()
+		assert(err == nil, err)
+		assert(data[0] == tt.code && len(data) == tt.size, tt.t, data)
+		var v Variant
+		assert(v.UnmarshalMsgpack(data) == nil, data)
+		assert(v.Type == Time && v.ToTime().Equal(tt.t), tt.t, v)
+	}
+}
+
+func TestMsgpackStream(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewMsgpackEncoder(&buf)
+	big := New(bytes.Repeat([]byte{7}, 3*msgpackChunk))
+	values := []Variant{New(1), New("two"), big, New([]any{map[string]any{"k": 3.5}})}
+	for _, v := range values {
+		assert(enc.Encode(v) == nil)
+	}
+
+	dec := NewMsgpackDecoder(&buf)
+	for _, want := range values {
+		var v Variant
+		assert(dec.Decode(&v) == nil)
+		assert(v.ToString() == want.ToString(), v)
+	}
+	var v Variant
+	assert(dec.Decode(&v) == io.EOF)
+}
+
+func TestVariant_UnmarshalMsgpackErrors(t *testing.T) {
+	tests := []struct {
+		data []byte
+		err  error
+	}{
+		{nil, io.ErrUnexpectedEOF},
+		{[]byte{0xa3, 'a'}, io.ErrUnexpectedEOF},
+		{[]byte{0x92, 0x01}, io.ErrUnexpectedEOF},
+		{[]byte{0xc6, 0xff, 0xff, 0xff, 0xff}, io.ErrUnexpectedEOF},
+		{[]byte{0xd4, 0x05, 0x00}, ErrUnsupported},
+		{[]byte{0xc1}, nil},
+		{[]byte{0x01, 0x02}, nil},
+		{[]byte{0xd6, 0xff, 0, 0, 0}, io.ErrUnexpectedEOF},
+		{[]byte{0x81, 0x90, 0x01}, ErrUnsupported}, // list key
+	}
+	for _, tt := range tests {
+		var v Variant
+		err := v.UnmarshalMsgpack(tt.data)
+		assert(err != nil, tt.data)
+		assert(tt.err == nil || errors.Is(err, tt.err), tt.data, err)
+	}
+}
+
+func TestVariant_UnmarshalMsgpackDepth(t *testing.T) {
+	// 2 MB of nested arrays of one element
+	data := bytes.Repeat([]byte{0x91}, 2<<20)
+	var v Variant
+	err := v.UnmarshalMsgpack(data)
+	assert(errors.Is(err, errDepth), err)
+	err = NewMsgpackDecoder(bytes.NewReader(data)).Decode(&v)
+	assert(errors.Is(err, errDepth), err)
+
+	data = append(bytes.Repeat([]byte{0x91}, maxDepth), 0x80)
+	assert(v.UnmarshalMsgpack(data) == nil)
+	data = append(bytes.Repeat([]byte{0x81, 0xa1, 'k'}, maxDepth), 0x01)
+	assert(v.UnmarshalMsgpack(data) == nil)
+	data = append(bytes.Repeat([]byte{0x81, 0xa1, 'k'}, maxDepth+1), 0x01)
+	err = v.UnmarshalMsgpack(data)
+	assert(errors.Is(err, errDepth), err)
+}
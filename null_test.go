@@ -0,0 +1,66 @@
+package variant
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVariant_Null(t *testing.T) {
+	var p *int64
+	v := New(p)
+	assert(v.Type == Int64)
+	assert(v.IsNull() && v.IsValid())
+	assert(v.String() == "Variant(int64, null)", v.String())
+	assert(v.ToInt64() == 0)
+	_, err := v.TryInt64()
+	assert(errors.Is(err, ErrNull), err)
+	_, err = v.TryInt8()
+	assert(errors.Is(err, ErrNull), err)
+	_, err = v.TryBool()
+	assert(errors.Is(err, ErrNull), err)
+	_, err = TryTo[time.Duration](v)
+	assert(errors.Is(err, ErrNull), err)
+
+	assert(New((*time.Time)(nil)).Type == Time)
+	assert(New((*string)(nil)).Equal(NewNull(String)))
+	assert(!New((*string)(nil)).Equal(New("")))
+	assert(!New((*string)(nil)).Equal(NewNull(Int)))
+
+	assert(Nil.IsNull() && !Nil.IsValid())
+	assert(NewNull(Invalid).IsNull() && !NewNull(Invalid).IsValid())
+	assert(!New(0).IsNull() && New(0).IsValid())
+
+	l := NewNull(List)
+	l.Append(1)
+	assert(!l.IsNull() && l.Len() == 1)
+	m := NewNull(Map)
+	m.Set("a", 1)
+	assert(!m.IsNull() && m.Get("a").ToInt() == 1)
+}
+
+func TestVariant_NullCodec(t *testing.T) {
+	var s *string
+	b, err := json.Marshal(map[string]Variant{"s": New(s), "t": NewNull(Time)})
+	assert(err == nil, err)
+	assert(string(b) == `{"s":null,"t":null}`, string(b))
+
+	y, err := New(s).MarshalYAML()
+	assert(err == nil && y == nil, err)
+
+	// decoding null keeps the kind the target declares
+	v := New(int16(5))
+	assert(json.Unmarshal([]byte("null"), &v) == nil)
+	assert(v.Type == Int16 && v.IsNull())
+	var u Variant
+	assert(json.Unmarshal([]byte("null"), &u) == nil)
+	assert(u.Type == Invalid && u.IsNull())
+
+	// and so does scanning a NULL, which binds back as NULL
+	col := NewNull(Int64)
+	assert(col.Scan(nil) == nil)
+	assert(col.Type == Int64 && col.IsNull())
+	val, err := col.Value()
+	assert(err == nil && val == nil, err)
+}
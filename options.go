@@ -0,0 +1,446 @@
+package variant
+
+import (
+	"math"
+	"math/big"
+	"time"
+)
+
+// Options configure the conversions of a Policy. The zero value converts like
+// the methods of Variant do out of the box.
+type Options struct {
+	// Location is the time zone of the times converted from numbers and
+	// other times, and the one strings without a zone offset are parsed in.
+	// Times are formatted in it too. Nil keeps the zone of times and parses
+	// strings in UTC.
+	Location *time.Location
+
+	// EpochUnit is the unit of the numbers converted to and from times,
+	// counted since the Unix epoch, e.g. time.Second for Unix timestamps.
+	// Zero means nanoseconds.
+	EpochUnit time.Duration
+
+	// DurationUnit is the unit of the numbers converted to and from
+	// durations. Zero means the package variable DurationUnit.
+	DurationUnit time.Duration
+
+	// Strict rejects the conversions to integers that lose part of the value,
+	// which are otherwise truncated: fractional parts that are not zero, of
+	// numbers and strings alike, imaginary parts and durations that are not a
+	// multiple of DurationUnit. They are reported as ErrInexact. Strings
+	// converted to times must match the layout, rather than being numbers.
+	Strict bool
+
+	// Saturate clamps the values out of the range of an integer type to its
+	// nearest bound, instead of reporting ErrOverflow. NaN is never clamped.
+	Saturate bool
+
+	// Locale is the notation of the numbers parsed from strings and of the
+	// ones ToString writes. Nil is the notation of Go, as strconv writes it.
+	Locale *Locale
+
+	// Rounding is how numbers with a fractional part, floats, decimals and
+	// decimal strings alike, are rounded when converted to integers.
+	Rounding RoundingMode
+}
+
+// A Policy converts variants according to its Options. Different parts of a
+// program can each hold a Policy of their own, it is safe for concurrent use.
+type Policy struct {
+	opts Options
+}
+
+// Default is the Policy the methods of Variant convert with, unless the
+// variant was bound to another one with With. Replacing it changes how every
+// variant is converted.
+var Default = NewConverter(Options{})
+
+// NewConverter returns a Policy converting with opts.
+func NewConverter(opts Options) *Policy {
+	return &Policy{opts: opts}
+}
+
+// Options returns the options of p.
+func (p *Policy) Options() Options {
+	return p.opts
+}
+
+// With returns a copy of v whose methods convert with p, so v.With(p).ToInt()
+// is p.ToInt(v). The copy can also be passed to To and TryTo.
+func (v Variant) With(p *Policy) Variant {
+	v.policy = p
+	return v
+}
+
+// ToBytes converts v like Variant.ToBytes, with the options of p.
+func (p *Policy) ToBytes(v Variant) []byte {
+	return v.With(p).ToBytes()
+}
+
+// TryBytes converts v like Variant.TryBytes, with the options of p.
+func (p *Policy) TryBytes(v Variant) ([]byte, error) {
+	return v.With(p).TryBytes()
+}
+
+// ToBool converts v like Variant.ToBool, with the options of p.
+func (p *Policy) ToBool(v Variant) bool {
+	return v.With(p).ToBool()
+}
+
+// TryBool converts v like Variant.TryBool, with the options of p.
+func (p *Policy) TryBool(v Variant) (bool, error) {
+	return v.With(p).TryBool()
+}
+
+// ToInt converts v like Variant.ToInt, with the options of p.
+func (p *Policy) ToInt(v Variant) int {
+	return v.With(p).ToInt()
+}
+
+// TryInt converts v like Variant.TryInt, with the options of p.
+func (p *Policy) TryInt(v Variant) (int, error) {
+	return v.With(p).TryInt()
+}
+
+// ToInt8 converts v like Variant.ToInt8, with the options of p.
+func (p *Policy) ToInt8(v Variant) int8 {
+	return v.With(p).ToInt8()
+}
+
+// TryInt8 converts v like Variant.TryInt8, with the options of p.
+func (p *Policy) TryInt8(v Variant) (int8, error) {
+	return v.With(p).TryInt8()
+}
+
+// ToInt16 converts v like Variant.ToInt16, with the options of p.
+func (p *Policy) ToInt16(v Variant) int16 {
+	return v.With(p).ToInt16()
+}
+
+// TryInt16 converts v like Variant.TryInt16, with the options of p.
+func (p *Policy) TryInt16(v Variant) (int16, error) {
+	return v.With(p).TryInt16()
+}
+
+// ToInt32 converts v like Variant.ToInt32, with the options of p.
+func (p *Policy) ToInt32(v Variant) int32 {
+	return v.With(p).ToInt32()
+}
+
+// TryInt32 converts v like Variant.TryInt32, with the options of p.
+func (p *Policy) TryInt32(v Variant) (int32, error) {
+	return v.With(p).TryInt32()
+}
+
+// ToInt64 converts v like Variant.ToInt64, with the options of p.
+func (p *Policy) ToInt64(v Variant) int64 {
+	return v.With(p).ToInt64()
+}
+
+// TryInt64 converts v like Variant.TryInt64, with the options of p.
+func (p *Policy) TryInt64(v Variant) (int64, error) {
+	return v.With(p).TryInt64()
+}
+
+// ToUint converts v like Variant.ToUint, with the options of p.
+func (p *Policy) ToUint(v Variant) uint {
+	return v.With(p).ToUint()
+}
+
+// TryUint converts v like Variant.TryUint, with the options of p.
+func (p *Policy) TryUint(v Variant) (uint, error) {
+	return v.With(p).TryUint()
+}
+
+// ToUint8 converts v like Variant.ToUint8, with the options of p.
+func (p *Policy) ToUint8(v Variant) uint8 {
+	return v.With(p).ToUint8()
+}
+
+// TryUint8 converts v like Variant.TryUint8, with the options of p.
+func (p *Policy) TryUint8(v Variant) (uint8, error) {
+	return v.With(p).TryUint8()
+}
+
+// ToUint16 converts v like Variant.ToUint16, with the options of p.
+func (p *Policy) ToUint16(v Variant) uint16 {
+	return v.With(p).ToUint16()
+}
+
+// TryUint16 converts v like Variant.TryUint16, with the options of p.
+func (p *Policy) TryUint16(v Variant) (uint16, error) {
+	return v.With(p).TryUint16()
+}
+
+// ToUint32 converts v like Variant.ToUint32, with the options of p.
+func (p *Policy) ToUint32(v Variant) uint32 {
+	return v.With(p).ToUint32()
+}
+
+// TryUint32 converts v like Variant.TryUint32, with the options of p.
+func (p *Policy) TryUint32(v Variant) (uint32, error) {
+	return v.With(p).TryUint32()
+}
+
+// ToUint64 converts v like Variant.ToUint64, with the options of p.
+func (p *Policy) ToUint64(v Variant) uint64 {
+	return v.With(p).ToUint64()
+}
+
+// TryUint64 converts v like Variant.TryUint64, with the options of p.
+func (p *Policy) TryUint64(v Variant) (uint64, error) {
+	return v.With(p).TryUint64()
+}
+
+// ToUintptr converts v like Variant.ToUintptr, with the options of p.
+func (p *Policy) ToUintptr(v Variant) uintptr {
+	return v.With(p).ToUintptr()
+}
+
+// TryUintptr converts v like Variant.TryUintptr, with the options of p.
+func (p *Policy) TryUintptr(v Variant) (uintptr, error) {
+	return v.With(p).TryUintptr()
+}
+
+// ToFloat32 converts v like Variant.ToFloat32, with the options of p.
+func (p *Policy) ToFloat32(v Variant) float32 {
+	return v.With(p).ToFloat32()
+}
+
+// TryFloat32 converts v like Variant.TryFloat32, with the options of p.
+func (p *Policy) TryFloat32(v Variant) (float32, error) {
+	return v.With(p).TryFloat32()
+}
+
+// ToFloat64 converts v like Variant.ToFloat64, with the options of p.
+func (p *Policy) ToFloat64(v Variant) float64 {
+	return v.With(p).ToFloat64()
+}
+
+// TryFloat64 converts v like Variant.TryFloat64, with the options of p.
+func (p *Policy) TryFloat64(v Variant) (float64, error) {
+	return v.With(p).TryFloat64()
+}
+
+// ToComplex64 converts v like Variant.ToComplex64, with the options of p.
+func (p *Policy) ToComplex64(v Variant) complex64 {
+	return v.With(p).ToComplex64()
+}
+
+// TryComplex64 converts v like Variant.TryComplex64, with the options of p.
+func (p *Policy) TryComplex64(v Variant) (complex64, error) {
+	return v.With(p).TryComplex64()
+}
+
+// ToComplex128 converts v like Variant.ToComplex128, with the options of p.
+func (p *Policy) ToComplex128(v Variant) complex128 {
+	return v.With(p).ToComplex128()
+}
+
+// TryComplex128 converts v like Variant.TryComplex128, with the options of p.
+func (p *Policy) TryComplex128(v Variant) (complex128, error) {
+	return v.With(p).TryComplex128()
+}
+
+// ToString converts v like Variant.ToString, with the options of p.
+func (p *Policy) ToString(v Variant) string {
+	return v.With(p).ToString()
+}
+
+// TryString converts v like Variant.TryString, with the options of p.
+func (p *Policy) TryString(v Variant) (string, error) {
+	return v.With(p).TryString()
+}
+
+// ToTime converts v like Variant.ToTime, with the options of p.
+func (p *Policy) ToTime(v Variant) time.Time {
+	return v.With(p).ToTime()
+}
+
+// TryTime converts v like Variant.TryTime, with the options of p.
+func (p *Policy) TryTime(v Variant) (time.Time, error) {
+	return v.With(p).TryTime()
+}
+
+// ToDuration converts v like Variant.ToDuration, with the options of p.
+func (p *Policy) ToDuration(v Variant) time.Duration {
+	return v.With(p).ToDuration()
+}
+
+// TryDuration converts v like Variant.TryDuration, with the options of p.
+func (p *Policy) TryDuration(v Variant) (time.Duration, error) {
+	return v.With(p).TryDuration()
+}
+
+// ToBigInt converts v like Variant.ToBigInt, with the options of p.
+func (p *Policy) ToBigInt(v Variant) *big.Int {
+	return v.With(p).ToBigInt()
+}
+
+// TryBigInt converts v like Variant.TryBigInt, with the options of p.
+func (p *Policy) TryBigInt(v Variant) (*big.Int, error) {
+	return v.With(p).TryBigInt()
+}
+
+// ToBigFloat converts v like Variant.ToBigFloat, with the options of p.
+func (p *Policy) ToBigFloat(v Variant) *big.Float {
+	return v.With(p).ToBigFloat()
+}
+
+// TryBigFloat converts v like Variant.TryBigFloat, with the options of p.
+func (p *Policy) TryBigFloat(v Variant) (*big.Float, error) {
+	return v.With(p).TryBigFloat()
+}
+
+// ToDecimal converts v like Variant.ToDecimal, with the options of p.
+func (p *Policy) ToDecimal(v Variant) Dec {
+	return v.With(p).ToDecimal()
+}
+
+// TryDecimal converts v like Variant.TryDecimal, with the options of p.
+func (p *Policy) TryDecimal(v Variant) (Dec, error) {
+	return v.With(p).TryDecimal()
+}
+
+// Strict returns a copy of v converted like with its policy, but in strict
+// mode, e.g. v.Strict().TryInt() fails for 3.9 rather than returning 3.
+func (v Variant) Strict() Variant {
+	o := *v.options()
+	o.Strict = true
+	return v.With(NewConverter(o))
+}
+
+// Saturating returns a copy of v converted like with its policy, but clamping
+// the values out of the range of integer types, e.g. v.Saturating().ToInt8()
+// is 127 for 300.
+func (v Variant) Saturating() Variant {
+	o := *v.options()
+	o.Saturate = true
+	return v.With(NewConverter(o))
+}
+
+// Round returns a copy of v converted like with its policy, but rounding to
+// integers with mode, e.g. v.Round(RoundHalfEven).ToInt() is 2 for "2.5".
+func (v Variant) Round(mode RoundingMode) Variant {
+	o := *v.options()
+	o.Rounding = mode
+	return v.With(NewConverter(o))
+}
+
+// options returns the options v is converted with.
+func (v Variant) options() *Options {
+	switch {
+	case v.policy != nil:
+		return &v.policy.opts
+	case Default != nil:
+		return &Default.opts
+	}
+	return &Options{}
+}
+
+// durationUnit returns the unit of the numbers v converts to and from
+// durations.
+func (v Variant) durationUnit() time.Duration {
+	switch o := v.options(); {
+	case o.DurationUnit > 0:
+		return o.DurationUnit
+	case DurationUnit > 0:
+		return DurationUnit
+	}
+	return time.Nanosecond
+}
+
+// epochUnit returns the unit of the numbers v converts to and from times.
+func (v Variant) epochUnit() time.Duration {
+	if o := v.options(); o.EpochUnit > 0 {
+		return o.EpochUnit
+	}
+	return time.Nanosecond
+}
+
+// inLocation returns t in the location of the options of v, if any.
+func (v Variant) inLocation(t time.Time) time.Time {
+	if loc := v.options().Location; loc != nil {
+		return t.In(loc)
+	}
+	return t
+}
+
+// epochTime returns the time n EpochUnit after the Unix epoch, or ErrOverflow.
+func epochTime(v Variant, n int64) (time.Time, error) {
+	unit := v.epochUnit()
+	if unit%time.Second == 0 {
+		s := int64(unit / time.Second)
+		if n > math.MaxInt64/s || n < math.MinInt64/s {
+			return time.Time{}, ErrOverflow
+		}
+		return v.inLocation(time.Unix(n*s, 0)), nil
+	}
+	if n > math.MaxInt64/int64(unit) || n < math.MinInt64/int64(unit) {
+		return time.Time{}, ErrOverflow
+	}
+	return v.inLocation(time.Unix(0, n*int64(unit))), nil
+}
+
+// epochTimeFloat is like epochTime for a fractional number of EpochUnit,
+// truncated to the nanosecond.
+func epochTimeFloat(v Variant, f float64) (time.Time, error) {
+	ns := f * float64(v.epochUnit())
+	if math.IsNaN(ns) || ns < math.MinInt64 || ns >= math.MaxInt64 {
+		return time.Time{}, ErrOverflow
+	}
+	return v.inLocation(time.Unix(0, int64(ns))), nil
+}
+
+// epochTimeDec is like epochTime for a decimal number of EpochUnit, truncated
+// to the nanosecond.
+func epochTimeDec(v Variant, d Dec) (time.Time, error) {
+	ns := d.Mul(NewDec(int64(v.epochUnit()), 0)).integer()
+	if !ns.IsInt64() {
+		return time.Time{}, ErrOverflow
+	}
+	return v.inLocation(time.Unix(0, ns.Int64())), nil
+}
+
+// epochNumber returns the number of whole EpochUnit between the Unix epoch
+// and t.
+func epochNumber(v Variant, t time.Time) int64 {
+	unit := v.epochUnit()
+	if unit%time.Second == 0 {
+		return t.Unix() / int64(unit/time.Second)
+	}
+	return t.UnixNano() / int64(unit)
+}
+
+// epochFloat returns the number of EpochUnit, fractional part included,
+// between the Unix epoch and t.
+func epochFloat(v Variant, t time.Time) float64 {
+	unit := v.epochUnit()
+	if unit == time.Nanosecond {
+		return float64(t.UnixNano())
+	}
+	return (float64(t.Unix())*1e9 + float64(t.Nanosecond())) / float64(unit)
+}
+
+// epochDec returns the exact number of EpochUnit between the Unix epoch and t.
+func epochDec(v Variant, t time.Time) Dec {
+	ns := new(big.Int).Mul(big.NewInt(t.Unix()), big.NewInt(1e9))
+	ns.Add(ns, big.NewInt(int64(t.Nanosecond())))
+	unit := NewDec(int64(v.epochUnit()), 0)
+	return NewDecFromBigInt(ns, 0).Quo(unit, 9, RoundHalfEven).reduce()
+}
+
+// roundFloat rounds f to an integer with the rounding mode of v.
+func roundFloat(v Variant, f float64) float64 {
+	switch v.options().Rounding {
+	case RoundHalfEven:
+		return math.RoundToEven(f)
+	case RoundHalfUp:
+		return math.Round(f)
+	case RoundFloor:
+		return math.Floor(f)
+	case RoundCeiling:
+		return math.Ceil(f)
+	}
+	return math.Trunc(f)
+}
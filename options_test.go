@@ -0,0 +1,299 @@
+package variant
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestPolicy_Location(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	p := NewConverter(Options{Location: loc})
+	tt := p.ToTime(New("2024-05-01 12:00:00"))
+	assert(tt.Location() == loc && tt.Hour() == 12, tt)
+	assert(tt.Equal(time.Date(2024, 5, 1, 4, 0, 0, 0, time.UTC)), tt)
+	s := p.ToString(New(time.Date(2024, 5, 1, 4, 0, 0, 0, time.UTC)))
+	assert(s == "2024-05-01 12:00:00", s)
+	s = New(time.Date(2024, 5, 1, 4, 0, 0, 0, time.UTC)).ToString()
+	assert(s == "2024-05-01 04:00:00", s)
+}
+
+func TestPolicy_EpochUnit(t *testing.T) {
+	tt := time.Date(2024, 5, 1, 0, 0, 0, 500_000_000, time.UTC)
+	ms := NewConverter(Options{EpochUnit: time.Millisecond})
+	sec := NewConverter(Options{EpochUnit: time.Second})
+	assert(ms.ToInt64(New(tt)) == 1714521600500, ms.ToInt64(New(tt)))
+	assert(sec.ToInt64(New(tt)) == 1714521600, sec.ToInt64(New(tt)))
+	assert(sec.ToFloat64(New(tt)) == 1714521600.5, sec.ToFloat64(New(tt)))
+	assert(sec.ToDecimal(New(tt)).String() == "1714521600.5", sec.ToDecimal(New(tt)))
+	assert(sec.ToTime(New(1714521600)).Equal(tt.Truncate(time.Second)))
+	assert(ms.ToTime(New(1714521600500)).Equal(tt))
+	assert(sec.ToTime(New(1714521600.5)).Equal(tt))
+	assert(sec.ToTime(New("1714521600")).Equal(tt.Truncate(time.Second)))
+	assert(New(1714521600500).ToTime().Equal(time.Unix(0, 1714521600500)))
+
+	_, err := ms.TryTime(New(int64(1) << 62))
+	assert(errors.Is(err, ErrOverflow), err)
+}
+
+func TestPolicy_DurationUnit(t *testing.T) {
+	p := NewConverter(Options{DurationUnit: time.Second})
+	assert(p.ToDuration(New(90)) == 90*time.Second, p.ToDuration(New(90)))
+	assert(p.ToInt(New(90*time.Second)) == 90, p.ToInt(New(90*time.Second)))
+	assert(New(90).ToDuration() == 90, New(90).ToDuration())
+}
+
+func TestPolicy_Strict(t *testing.T) {
+	p := NewConverter(Options{Strict: true})
+	targets := []Pair[error]{
+		{"12", nil},
+		{"12.00", nil},
+		{"12.5", ErrInexact},
+		{"-0.1", ErrInexact},
+	}
+	for _, pair := range targets {
+		t.Run("Strict", func(t *testing.T) {
+			_, err := p.TryInt(New(pair.Key))
+			assert(errors.Is(err, pair.Val), pair.Key, err)
+			_, err = p.TryUint64(New(pair.Key))
+			assert(errors.Is(err, pair.Val) || errors.Is(err, ErrSyntax), pair.Key, err)
+		})
+	}
+	assert(New("12.5").ToInt() == 12)
+
+	_, err := p.TryTime(New("1714521600"))
+	assert(errors.Is(err, ErrSyntax), err)
+}
+
+func TestPolicy_Rounding(t *testing.T) {
+	targets := []struct {
+		mode RoundingMode
+		f    float64
+		want int64
+	}{
+		{RoundTruncate, 2.5, 2},
+		{RoundTruncate, -2.5, -2},
+		{RoundHalfEven, 2.5, 2},
+		{RoundHalfEven, 3.5, 4},
+		{RoundHalfUp, 2.5, 3},
+		{RoundHalfUp, -2.5, -3},
+		{RoundFloor, -2.1, -3},
+		{RoundCeiling, 2.1, 3},
+	}
+	for _, tt := range targets {
+		p := NewConverter(Options{Rounding: tt.mode})
+		assert(p.ToInt64(New(tt.f)) == tt.want, tt.mode, tt.f, p.ToInt64(New(tt.f)))
+		assert(p.ToInt(New(float32(tt.f))) == int(tt.want), tt.mode, tt.f)
+	}
+	p := NewConverter(Options{Rounding: RoundCeiling})
+	assert(p.ToUint8(New(2.1)) == 3, p.ToUint8(New(2.1)))
+	assert(p.ToInt32(New(complex(2.1, 1))) == 3)
+}
+
+func TestPolicy_Default(t *testing.T) {
+	saved := Default
+	defer func() { Default = saved }()
+	Default = NewConverter(Options{Rounding: RoundHalfUp})
+	assert(New(2.5).ToInt() == 3, New(2.5).ToInt())
+	assert(New(2.5).With(NewConverter(Options{})).ToInt() == 2)
+}
+
+func TestVariant_With(t *testing.T) {
+	p := NewConverter(Options{Rounding: RoundHalfUp})
+	v := New(2.5).With(p)
+	assert(v.ToInt() == 3)
+	assert(To[int16](v) == 3, To[int16](v))
+	assert(New(2.5).ToInt() == 2)
+	assert(p.Options().Rounding == RoundHalfUp)
+}
+
+func TestVariant_Strict(t *testing.T) {
+	targets := []Pair[error]{
+		{3.0, nil},
+		{3.9, ErrInexact},
+		{float32(-0.5), ErrInexact},
+		{complex(3, 0), nil},
+		{complex(3, 1), ErrInexact},
+		{"3.000", nil},
+		{"3.5", ErrInexact},
+		{NewDec(35, 1), ErrInexact},
+		{NewDec(30, 1), nil},
+		{big.NewFloat(2.5), ErrInexact},
+		{math.NaN(), ErrOverflow},
+		{math.Inf(1), ErrOverflow},
+		{uint64(math.MaxUint64), ErrOverflow},
+	}
+	for _, pair := range targets {
+		t.Run("Strict", func(t *testing.T) {
+			_, err := New(pair.Key).Strict().TryInt64()
+			assert(errors.Is(err, pair.Val), pair.Key, err)
+			_, err = New(pair.Key).Strict().TryBigInt()
+			assert(pair.Val == ErrOverflow || errors.Is(err, pair.Val), pair.Key, err)
+		})
+	}
+	assert(New(3.9).ToInt64() == 3)
+	_, err := New(3.9).Strict().TryInt8()
+	assert(errors.Is(err, ErrInexact), err)
+
+	p := NewConverter(Options{Strict: true, DurationUnit: time.Second})
+	_, err = p.TryInt(New(1500 * time.Millisecond))
+	assert(errors.Is(err, ErrInexact), err)
+	assert(p.ToInt(New(2*time.Second)) == 2)
+}
+
+func TestVariant_Saturating(t *testing.T) {
+	assert(New(300).Saturating().ToInt8() == math.MaxInt8)
+	assert(New(-300).Saturating().ToInt8() == math.MinInt8)
+	assert(New(-1).Saturating().ToUint() == 0)
+	assert(New(70000).Saturating().ToUint16() == math.MaxUint16)
+	assert(New(uint64(math.MaxUint64)).Saturating().ToInt64() == math.MaxInt64)
+	assert(New(math.Inf(1)).Saturating().ToInt64() == math.MaxInt64)
+	assert(New(math.Inf(-1)).Saturating().ToInt() == math.MinInt)
+	assert(New(1e30).Saturating().ToUint64() == math.MaxUint64)
+	assert(New(float32(-1e30)).Saturating().ToUint32() == 0)
+	assert(New("99999999999999999999").Saturating().ToInt64() == math.MaxInt64)
+	assert(New("-99999999999999999999").Saturating().ToInt32() == math.MinInt32)
+	assert(New(new(big.Int).Lsh(big.NewInt(1), 100)).Saturating().ToUint64() == math.MaxUint64)
+	assert(New(NewDec(-1, -30)).Saturating().ToInt64() == math.MinInt64)
+	assert(New(-time.Hour).Saturating().ToUint() == 0)
+
+	_, err := New(300).TryInt8()
+	assert(errors.Is(err, ErrOverflow), err)
+	_, err = New(math.NaN()).Saturating().TryInt()
+	assert(errors.Is(err, ErrOverflow), err)
+
+	p := NewConverter(Options{Strict: true, Saturate: true})
+	assert(p.ToInt8(New(1e10)) == math.MaxInt8)
+	_, err = p.TryInt8(New(1.5))
+	assert(errors.Is(err, ErrInexact), err)
+}
+
+func TestVariant_StrictFloat(t *testing.T) {
+	targets := []Pair[error]{
+		{0.5, nil},
+		{16777216, nil},
+		{int64(-1 << 40), nil},
+		{uint64(math.MaxUint64), ErrInexact},
+		{16777217, ErrInexact},
+		{0.1, ErrInexact},
+		{1e300, ErrOverflow},
+		{-1e300, ErrOverflow},
+		{complex(1e300, 0), ErrOverflow},
+		{math.Inf(1), nil},
+		{math.NaN(), nil},
+		{big.NewFloat(1e300), ErrOverflow},
+		{big.NewFloat(0.1), ErrInexact},
+		{new(big.Int).Lsh(big.NewInt(1), 200), ErrOverflow},
+	}
+	for _, pair := range targets {
+		t.Run("StrictFloat32", func(t *testing.T) {
+			_, err := New(pair.Key).Strict().TryFloat32()
+			assert(errors.Is(err, pair.Val), pair.Key, err)
+		})
+	}
+	assert(New(16777217).ToFloat32() == 16777216)
+	assert(math.IsInf(float64(New(1e300).ToFloat32()), 1))
+
+	_, err := New(int64(1<<53 + 1)).Strict().TryFloat64()
+	assert(errors.Is(err, ErrInexact), err)
+	_, err = New(uint64(math.MaxUint64)).Strict().TryFloat64()
+	assert(errors.Is(err, ErrInexact), err)
+	assert(New(int64(1<<53)).Strict().ToFloat64() == 1<<53)
+	_, err = New(big.NewInt(1<<53 + 1)).Strict().TryFloat64()
+	assert(errors.Is(err, ErrInexact), err)
+
+	assert(New(1e300).Saturating().ToFloat32() == math.MaxFloat32)
+	assert(New(-1e300).Saturating().ToFloat32() == -math.MaxFloat32)
+	assert(New("1e300").Saturating().ToFloat32() == math.MaxFloat32)
+	assert(New("-1e400").Saturating().ToFloat64() == -math.MaxFloat64)
+	assert(New(big.NewFloat(-1e300)).Saturating().ToFloat32() == -math.MaxFloat32)
+	assert(New(new(big.Int).Lsh(big.NewInt(1), 2000)).Saturating().ToFloat64() == math.MaxFloat64)
+	assert(New(0.1).Saturating().ToFloat32() == float32(0.1))
+	_, err = New("1e300").TryFloat32()
+	assert(errors.Is(err, ErrOverflow), err)
+}
+
+func Test_intRange(t *testing.T) {
+	lo8, hi8 := intRange[int8]()
+	assert(lo8 == math.MinInt8 && hi8 == math.MaxInt8, lo8, hi8)
+	lo, hi := intRange[int64]()
+	assert(lo == math.MinInt64 && hi == math.MaxInt64, lo, hi)
+	ulo, uhi := intRange[uint32]()
+	assert(ulo == 0 && uhi == math.MaxUint32, ulo, uhi)
+}
+
+func TestVariant_Round(t *testing.T) {
+	values := []string{"2.5", "-2.5", "3.5", "2.4", "-2.6", "2.500001"}
+	targets := []struct {
+		mode RoundingMode
+		want []int64
+	}{
+		{RoundTruncate, []int64{2, -2, 3, 2, -2, 2}},
+		{RoundHalfEven, []int64{2, -2, 4, 2, -3, 3}},
+		{RoundHalfUp, []int64{3, -3, 4, 2, -3, 3}},
+		{RoundFloor, []int64{2, -3, 3, 2, -3, 2}},
+		{RoundCeiling, []int64{3, -2, 4, 3, -2, 3}},
+	}
+	for _, tt := range targets {
+		for i, s := range values {
+			want := tt.want[i]
+			d, _ := ParseDec(s)
+			f, _ := parseBigFloat(s)
+			for _, v := range []Variant{New(s), New(New(s).ToFloat64()), New(float32(New(s).ToFloat64())), New(d), New(f)} {
+				v = v.Round(tt.mode)
+				assert(v.ToInt64() == want, tt.mode, v, v.ToInt64())
+				assert(v.ToInt() == int(want), tt.mode, v, v.ToInt())
+				assert(v.ToInt8() == int8(want), tt.mode, v, v.ToInt8())
+				assert(v.ToBigInt().Int64() == want, tt.mode, v, v.ToBigInt())
+				if want > 0 {
+					assert(v.ToUint64() == uint64(want), tt.mode, v, v.ToUint64())
+					assert(v.ToUint16() == uint16(want), tt.mode, v, v.ToUint16())
+				}
+			}
+		}
+	}
+
+	// negative fractions convert to unsigned integers when rounded to 0
+	for _, tt := range []struct {
+		mode RoundingMode
+		s    string
+		err  error
+	}{
+		{RoundTruncate, "-0.9", nil},
+		{RoundHalfEven, "-0.4", nil},
+		{RoundHalfEven, "-0.5", nil},
+		{RoundHalfEven, "-0.6", ErrOverflow},
+		{RoundHalfUp, "-0.4", nil},
+		{RoundHalfUp, "-0.5", ErrOverflow},
+		{RoundFloor, "-0.1", ErrOverflow},
+		{RoundCeiling, "-0.5", nil},
+		{RoundCeiling, "-0.9", nil},
+		{RoundCeiling, "-1", ErrOverflow},
+	} {
+		d, _ := ParseDec(tt.s)
+		f, _ := parseBigFloat(tt.s)
+		for _, v := range []Variant{New(tt.s), New(New(tt.s).ToFloat64()), New(float32(New(tt.s).ToFloat64())), New(d), New(f)} {
+			v = v.Round(tt.mode)
+			n, err := v.TryUint()
+			assert(errors.Is(err, tt.err) && n == 0, tt.mode, v, n, err)
+			_, err = v.TryUint8()
+			assert(errors.Is(err, tt.err), tt.mode, v, err)
+			_, err = v.TryUint64()
+			assert(errors.Is(err, tt.err), tt.mode, v, err)
+		}
+	}
+
+	_, err := New("18446744073709551615.5").Round(RoundHalfUp).TryUint64()
+	assert(errors.Is(err, ErrOverflow), err)
+	_, err = New("-9223372036854775808.5").Round(RoundFloor).TryInt64()
+	assert(errors.Is(err, ErrOverflow), err)
+	assert(New("-9223372036854775808.5").ToInt64() == math.MinInt64)
+
+	saved := Default
+	defer func() { Default = saved }()
+	Default = NewConverter(Options{Rounding: RoundHalfEven})
+	assert(New("0.5").ToInt() == 0 && New("1.5").ToInt() == 2)
+	assert(New("1.5").Round(RoundTruncate).ToInt() == 1)
+}
@@ -0,0 +1,279 @@
+package variant
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+)
+
+// maxExp10 bounds the exponents of the integers parsed, the numbers beyond
+// 10^maxExp10 are out of range of every integer type, BigInt included.
+const maxExp10 = 10000
+
+// parseInt parses s as a signed integer of the given bit size, written as a
+// Go integer or decimal floating-point literal (see scanLiteral). A fractional
+// part is accepted and rounded with the rounding mode of o, e.g. "-12.9"
+// yields -12 when truncating, unless o is strict, which reports ErrInexact for
+// one that is not all zeros.
+func parseInt(s []byte, bitSize int, o *Options) (int64, error) {
+	l, err := scanLiteral(s)
+	if err != nil {
+		return 0, err
+	}
+	n, err := l.uint64(o)
+	if err != nil {
+		return 0, err
+	}
+	cutoff := uint64(1) << uint(bitSize-1)
+	if l.neg {
+		if n > cutoff {
+			return 0, ErrOverflow
+		}
+		return -int64(n), nil
+	}
+	if n >= cutoff {
+		return 0, ErrOverflow
+	}
+	return int64(n), nil
+}
+
+// parseUint parses s as an unsigned integer of the given bit size, like
+// parseInt. A negative value, once rounded, is reported as ErrOverflow.
+func parseUint(s []byte, bitSize int, o *Options) (uint64, error) {
+	l, err := scanLiteral(s)
+	if err != nil {
+		return 0, err
+	}
+	n, err := l.uint64(o)
+	if err != nil {
+		return 0, err
+	}
+	if l.neg && n != 0 {
+		return 0, ErrOverflow
+	}
+	if bitSize < 64 && n > 1<<uint(bitSize)-1 {
+		return 0, ErrOverflow
+	}
+	return n, nil
+}
+
+// parseBigInt parses s as an integer of arbitrary size, like parseInt.
+func parseBigInt(s []byte, o *Options) (*big.Int, error) {
+	l, err := scanLiteral(s)
+	if err != nil {
+		return nil, err
+	}
+	return l.bigInt(o)
+}
+
+// negative reports whether s, a number, starts with a minus sign.
+func negative(s []byte) bool {
+	s = bytes.TrimSpace(s)
+	return len(s) > 0 && s[0] == '-'
+}
+
+// A literal is a number split by scanLiteral, whose slices point into the
+// parsed text.
+type literal struct {
+	neg  bool
+	base uint64
+	mant []byte // the digits of the mantissa, with its underscores and point
+	exp  int    // the decimal exponent
+	sci  bool   // whether the mantissa is followed by an exponent
+}
+
+// scanLiteral splits s, surrounded by optional white space, into a literal.
+// It accepts the notations of the Go integer literals, with an optional sign,
+// the 0x, 0o and 0b prefixes and underscores between digits, and decimal
+// numbers with a fractional part and an exponent, e.g. "1.5e3". A leading 0
+// is not an octal prefix, "017" is 17.
+func scanLiteral(s []byte) (literal, error) {
+	l := literal{base: 10}
+	s = bytes.TrimSpace(s)
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		l.neg = s[0] == '-'
+		s = s[1:]
+	}
+	prefixed := false
+	if len(s) > 1 && s[0] == '0' {
+		switch s[1] | 0x20 {
+		case 'x':
+			l.base, prefixed = 16, true
+		case 'o':
+			l.base, prefixed = 8, true
+		case 'b':
+			l.base, prefixed = 2, true
+		}
+		if prefixed {
+			s = s[2:]
+		}
+	}
+
+	isDigit := func(i int) bool { return i >= 0 && i < len(s) && digitValue(s[i]) < l.base }
+	i, digits, point := 0, 0, false
+scan:
+	for ; i < len(s); i++ {
+		switch ch := s[i]; {
+		case digitValue(ch) < l.base:
+			digits++
+		case ch == '_':
+			// between digits, or after the prefix
+			if !isDigit(i-1) && !(prefixed && i == 0) || !isDigit(i+1) {
+				return l, ErrSyntax
+			}
+		case ch == '.' && l.base == 10 && !point:
+			if !isDigit(i-1) || !isDigit(i+1) {
+				return l, ErrSyntax
+			}
+			point = true
+		default:
+			break scan
+		}
+	}
+	if digits == 0 {
+		return l, ErrSyntax
+	}
+	l.mant, s = s[:i], s[i:]
+	if len(s) == 0 {
+		return l, nil
+	}
+
+	if l.base != 10 || s[0]|0x20 != 'e' {
+		return l, ErrSyntax
+	}
+	l.sci = true
+	s = s[1:]
+	neg := false
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if len(s) == 0 {
+		return l, ErrSyntax
+	}
+	for i := range s {
+		switch ch := s[i]; {
+		case ch >= '0' && ch <= '9':
+			if l.exp <= maxExp10 {
+				l.exp = l.exp*10 + int(ch-'0')
+			}
+		case ch == '_' && isDigit(i-1) && isDigit(i+1):
+		default:
+			return l, ErrSyntax
+		}
+	}
+	if neg {
+		l.exp = -l.exp
+	}
+	return l, nil
+}
+
+// digitValue returns the value of the digit ch, in any base up to 16, or
+// 0xff if ch is not a digit.
+func digitValue(ch byte) uint64 {
+	switch {
+	case ch >= '0' && ch <= '9':
+		return uint64(ch - '0')
+	case ch|0x20 >= 'a' && ch|0x20 <= 'f':
+		return uint64(ch|0x20-'a') + 10
+	}
+	return 0xff
+}
+
+// split calls fn with each digit of the integer part of l, in order. It
+// returns the number of zeros the exponent appends to them, how the fraction
+// discarded compares to one half, and whether the fraction is not zero.
+func (l *literal) split(fn func(d uint64)) (zeros, half int, frac bool) {
+	m := l.exp // the number of digits before the point, once shifted
+	for _, ch := range l.mant {
+		if ch == '.' {
+			break
+		}
+		if ch != '_' {
+			m++
+		}
+	}
+	if m < 0 {
+		half = -1 // the fraction starts with a 0
+	}
+	k := 0
+	for _, ch := range l.mant {
+		if ch == '_' || ch == '.' {
+			continue
+		}
+		d := digitValue(ch)
+		switch {
+		case k < m:
+			fn(d)
+		case k == m && d != 5:
+			half = int(d) - 5
+		case k > m && d != 0 && half == 0:
+			half = 1
+		}
+		frac = frac || k >= m && d != 0
+		k++
+	}
+	return max(m-k, 0), half, frac
+}
+
+// uint64 returns the magnitude of l, its fraction rounded like o. Fractions
+// are reported as ErrInexact if o is strict, or if l has an exponent, which
+// must denote an integer.
+func (l *literal) uint64(o *Options) (uint64, error) {
+	var n uint64
+	overflow := false
+	zeros, half, frac := l.split(func(d uint64) {
+		if n > (math.MaxUint64-d)/l.base {
+			overflow = true
+		}
+		n = n*l.base + d
+	})
+	for ; zeros > 0 && n != 0 && !overflow; zeros-- {
+		overflow = n > math.MaxUint64/10
+		n *= 10
+	}
+	if overflow {
+		return 0, ErrOverflow
+	}
+	switch {
+	case !frac:
+	case l.sci || o.Strict:
+		return 0, ErrInexact
+	case roundAway(o.Rounding, l.neg, half, n&1 == 1):
+		if n == math.MaxUint64 {
+			return 0, ErrOverflow
+		}
+		n++
+	}
+	return n, nil
+}
+
+// bigInt is like uint64 for integers of arbitrary size, and includes the sign
+// of l.
+func (l *literal) bigInt(o *Options) (*big.Int, error) {
+	buf := make([]byte, 0, len(l.mant))
+	zeros, half, frac := l.split(func(d uint64) {
+		buf = append(buf, "0123456789abcdef"[d])
+	})
+	b := new(big.Int)
+	if len(buf) > 0 {
+		b.SetString(string(buf), int(l.base))
+	}
+	if zeros > 0 && b.Sign() != 0 {
+		if zeros > maxExp10 {
+			return nil, ErrOverflow
+		}
+		b.Mul(b, pow10(int64(zeros)))
+	}
+	switch {
+	case !frac:
+	case l.sci || o.Strict:
+		return nil, ErrInexact
+	case roundAway(o.Rounding, l.neg, half, b.Bit(0) == 1):
+		b.Add(b, big.NewInt(1))
+	}
+	if l.neg {
+		b.Neg(b)
+	}
+	return b, nil
+}
@@ -0,0 +1,141 @@
+package variant
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func Test_parseInt(t *testing.T) {
+	targets := []struct {
+		s    string
+		want int64
+		err  error
+	}{
+		{"42", 42, nil},
+		{" 42 ", 42, nil},
+		{"\t-42\n", -42, nil},
+		{"+7", 7, nil},
+		{"017", 17, nil},
+		{"0x1F", 31, nil},
+		{"0X1f", 31, nil},
+		{"-0x80", -128, nil},
+		{"0o17", 15, nil},
+		{"0O17", 15, nil},
+		{"0b101", 5, nil},
+		{"0b_1010_1010", 170, nil},
+		{"0x_FF", 255, nil},
+		{"1_000_000", 1000000, nil},
+		{"1e3", 1000, nil},
+		{"1E+3", 1000, nil},
+		{"1.5e3", 1500, nil},
+		{"-2.50e1", -25, nil},
+		{"1_0e0_2", 1000, nil},
+		{"150e-1", 15, nil},
+		{"0e99999", 0, nil},
+		{"12.9", 12, nil},
+		{"-12.9", -12, nil},
+		{"9223372036854775807", math.MaxInt64, nil},
+		{"-9223372036854775808", math.MinInt64, nil},
+		{"-0x8000000000000000", math.MinInt64, nil},
+		{"9.223372036854775807e18", math.MaxInt64, nil},
+
+		{"1.55e1", 0, ErrInexact},
+		{"1e-1", 0, ErrInexact},
+		{"9223372036854775808", 0, ErrOverflow},
+		{"0x8000000000000000", 0, ErrOverflow},
+		{"1e19", 0, ErrOverflow},
+		{"1e99999", 0, ErrOverflow},
+		{"", 0, ErrSyntax},
+		{"   ", 0, ErrSyntax},
+		{"-", 0, ErrSyntax},
+		{"0x", 0, ErrSyntax},
+		{"0b102", 0, ErrSyntax},
+		{"0o8", 0, ErrSyntax},
+		{"0x1.8", 0, ErrSyntax},
+		{"0x1e3", 0x1e3, nil},
+		{"0b1e3", 0, ErrSyntax},
+		{"_1", 0, ErrSyntax},
+		{"1_", 0, ErrSyntax},
+		{"1__0", 0, ErrSyntax},
+		{"1_.5", 0, ErrSyntax},
+		{"1._5", 0, ErrSyntax},
+		{"0_x1", 0, ErrSyntax},
+		{"1e", 0, ErrSyntax},
+		{"1e+", 0, ErrSyntax},
+		{"1e_3", 0, ErrSyntax},
+		{"1e3_", 0, ErrSyntax},
+		{"1 000", 0, ErrSyntax},
+		{".5", 0, ErrSyntax},
+		{"5.", 0, ErrSyntax},
+		{"1.2.3", 0, ErrSyntax},
+		{"--1", 0, ErrSyntax},
+		{"Inf", 0, ErrSyntax},
+	}
+	for _, tt := range targets {
+		t.Run(tt.s, func(t *testing.T) {
+			n, err := parseInt([]byte(tt.s), 64, &Options{})
+			assert(n == tt.want && errors.Is(err, tt.err), tt.s, n, err)
+			b, err := parseBigInt([]byte(tt.s), &Options{})
+			if tt.err == nil || tt.err == ErrOverflow && tt.s != "1e99999" {
+				assert(err == nil && (tt.err != nil || b.Int64() == tt.want), tt.s, b, err)
+			} else {
+				assert(errors.Is(err, tt.err), tt.s, b, err)
+			}
+		})
+	}
+}
+
+func Test_parseUint(t *testing.T) {
+	n, err := parseUint([]byte(" 0xFFFF_FFFF_FFFF_FFFF "), 64, &Options{})
+	assert(n == math.MaxUint64 && err == nil, n, err)
+	n, err = parseUint([]byte("1.8446744073709551615e19"), 64, &Options{})
+	assert(n == math.MaxUint64 && err == nil, n, err)
+	_, err = parseUint([]byte("1.8446744073709551616e19"), 64, &Options{})
+	assert(errors.Is(err, ErrOverflow), err)
+	_, err = parseUint([]byte("0x100"), 8, &Options{})
+	assert(errors.Is(err, ErrOverflow), err)
+	_, err = parseUint([]byte("-0x1"), 64, &Options{})
+	assert(errors.Is(err, ErrOverflow), err)
+	n, err = parseUint([]byte("-0"), 64, &Options{})
+	assert(n == 0 && err == nil, n, err)
+	n, err = parseUint([]byte("-0.4"), 64, &Options{})
+	assert(n == 0 && err == nil, n, err)
+	_, err = parseUint([]byte("-0.4"), 64, &Options{Rounding: RoundFloor})
+	assert(errors.Is(err, ErrOverflow), err)
+	_, err = parseUint([]byte("--1"), 64, &Options{})
+	assert(errors.Is(err, ErrSyntax), err)
+}
+
+func TestVariant_ToInt_literals(t *testing.T) {
+	assert(New("0x1F").ToInt() == 31)
+	assert(New("0o17").ToInt8() == 15)
+	assert(New("0b101").ToUint16() == 5)
+	assert(New("1_000_000").ToUint64() == 1000000)
+	assert(New("1e3").ToInt64() == 1000)
+	assert(New(" 42 ").ToUint() == 42)
+	assert(New("2.5e20").ToBigInt().String() == "250000000000000000000")
+	assert(New(" -0x1_0000_0000_0000_0000 ").Saturating().ToInt64() == math.MinInt64)
+	assert(New("-5").Saturating().ToUint() == 0 && New("-5").Saturating().ToUint8() == 0)
+	assert(New("-1e30").Saturating().ToUint64() == 0)
+	_, err := New("-5").TryUint()
+	assert(errors.Is(err, ErrOverflow), err)
+	assert(New("1.5").Round(RoundHalfUp).ToInt() == 2)
+	_, err = New("1.5e0").Round(RoundHalfUp).TryInt()
+	assert(errors.Is(err, ErrInexact), err)
+}
+
+func Test_parseInt_allocs(t *testing.T) {
+	inputs := []Variant{New(" 42 "), New("0x_dead_beef"), New("-1_000.5"), New("1.25e2")}
+	for _, v := range inputs {
+		allocs := testing.AllocsPerRun(100, func() {
+			if _, err := v.TryInt64(); err != nil {
+				t.Fatal(v, err)
+			}
+			if _, err := v.TryInt(); err != nil {
+				t.Fatal(v, err)
+			}
+		})
+		assert(allocs == 0, v, allocs)
+	}
+}
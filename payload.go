@@ -0,0 +1,275 @@
+package variant
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// The helpers below decode the payload of a variant as it was encoded by New.
+// An empty payload is the zero value of its kind, any other size that does not
+// match the encoding of the kind is reported as ErrTruncated.
+
+// payloadBool reports whether the payload of v, of any kind, has a byte that
+// is not zero.
+func payloadBool(v Variant) (bool, error) {
+	for _, ch := range v.Data {
+		if ch != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func payloadInt(v Variant) (int64, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 4:
+		return int64(int32(binary.BigEndian.Uint32(v.Data))), nil
+	case 8:
+		return int64(binary.BigEndian.Uint64(v.Data)), nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadInt8(v Variant) (int8, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 1:
+		return int8(v.Data[0]), nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadInt16(v Variant) (int16, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 2:
+		return int16(binary.BigEndian.Uint16(v.Data)), nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadInt32(v Variant) (int32, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 4:
+		return int32(binary.BigEndian.Uint32(v.Data)), nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadInt64(v Variant) (int64, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 8:
+		return int64(binary.BigEndian.Uint64(v.Data)), nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadUint(v Variant) (uint64, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(v.Data)), nil
+	case 8:
+		return binary.BigEndian.Uint64(v.Data), nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadUint8(v Variant) (uint8, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 1:
+		return v.Data[0], nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadUint16(v Variant) (uint16, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 2:
+		return binary.BigEndian.Uint16(v.Data), nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadUint32(v Variant) (uint32, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 4:
+		return binary.BigEndian.Uint32(v.Data), nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadUint64(v Variant) (uint64, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 8:
+		return binary.BigEndian.Uint64(v.Data), nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadFloat32(v Variant) (float32, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 4:
+		return math.Float32frombits(binary.BigEndian.Uint32(v.Data)), nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadFloat64(v Variant) (float64, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(v.Data)), nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadComplex64(v Variant) (complex64, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 8:
+		re := math.Float32frombits(binary.BigEndian.Uint32(v.Data))
+		im := math.Float32frombits(binary.BigEndian.Uint32(v.Data[4:]))
+		return complex(re, im), nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadComplex128(v Variant) (complex128, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 16:
+		re := math.Float64frombits(binary.BigEndian.Uint64(v.Data))
+		im := math.Float64frombits(binary.BigEndian.Uint64(v.Data[8:]))
+		return complex(re, im), nil
+	}
+	return 0, ErrTruncated
+}
+
+// payloadTime decodes a Time payload. Unlike the numeric kinds an empty
+// payload is not a valid time.
+func payloadTime(v Variant) (time.Time, error) {
+	var t time.Time
+	if err := t.UnmarshalBinary(v.Data); err != nil {
+		return time.Time{}, ErrTruncated
+	}
+	return t, nil
+}
+
+func payloadDuration(v Variant) (time.Duration, error) {
+	switch len(v.Data) {
+	case 0:
+		return 0, nil
+	case 8:
+		return time.Duration(binary.BigEndian.Uint64(v.Data)), nil
+	}
+	return 0, ErrTruncated
+}
+
+func payloadBigInt(v Variant) (*big.Int, error) {
+	b := new(big.Int)
+	if err := b.GobDecode(v.Data); err != nil {
+		return nil, ErrTruncated
+	}
+	return b, nil
+}
+
+func payloadBigFloat(v Variant) (*big.Float, error) {
+	f := new(big.Float)
+	if len(v.Data) == 0 {
+		return f, nil
+	}
+	if err := f.GobDecode(v.Data); err != nil {
+		return nil, ErrTruncated
+	}
+	return f, nil
+}
+
+// payloadBigFloatInt decodes a BigFloat payload and rounds it to an integer
+// like the options of v, see bigFloatInt.
+func payloadBigFloatInt(v Variant) (*big.Int, error) {
+	f, err := payloadBigFloat(v)
+	if err != nil {
+		return nil, err
+	}
+	return bigFloatInt(f, v.options())
+}
+
+// payloadDec decodes a Decimal payload: the scale as 4 bytes followed by the
+// gob encoding of the unscaled value.
+func payloadDec(v Variant) (Dec, error) {
+	switch {
+	case len(v.Data) == 0:
+		return Dec{}, nil
+	case len(v.Data) < 4:
+		return Dec{}, ErrTruncated
+	}
+	// the scale is bounded like the exponents of ParseDec, since formatting or
+	// rounding the number takes time and memory in proportion to it
+	scale := int32(binary.BigEndian.Uint32(v.Data))
+	if scale > maxDecExponent || scale < -maxDecExponent {
+		return Dec{}, ErrOverflow
+	}
+	b := new(big.Int)
+	if err := b.GobDecode(v.Data[4:]); err != nil {
+		return Dec{}, ErrTruncated
+	}
+	return Dec{unscaled: b, scale: scale}, nil
+}
+
+// payloadDecInt decodes a Decimal payload and rounds it to an integer with
+// the rounding mode of v. Fractional values are reported as ErrInexact if v is
+// strict.
+func payloadDecInt(v Variant) (*big.Int, error) {
+	d, err := payloadDec(v)
+	if err != nil {
+		return nil, err
+	}
+	o := v.options()
+	i := d.Round(0, o.Rounding).int()
+	if o.Strict && d.Cmp(NewDecFromBigInt(i, 0)) != 0 {
+		return nil, ErrInexact
+	}
+	return i, nil
+}
+
+// payloadDecFloat decodes a Decimal payload and rounds it to the nearest
+// floating-point number of the given bit size, parsing its exact decimal
+// representation. Values beyond the range of the type are reported as
+// ErrOverflow.
+func payloadDecFloat(v Variant, bitSize int) (float64, error) {
+	d, err := payloadDec(v)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(d.String(), bitSize)
+	if err != nil {
+		return 0, ErrOverflow
+	}
+	return f, nil
+}
@@ -0,0 +1,109 @@
+package variant
+
+import (
+	"maps"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// registry holds the strategies registered by users, keyed by target type.
+// Readers load the map without locking, writers replace it under mu.
+var registry struct {
+	mu sync.Mutex
+	m  atomic.Pointer[map[reflect.Type]any] // values are Converter[T]
+}
+
+// Register installs s as the strategy converting variants to T. The kinds s
+// handles are captured when Register is called, each of them takes precedence
+// over a conversion previously registered for T and over the built-in
+// strategies, so Register can also be used to change how ToInt, ToString,
+// etc. treat a particular kind.
+func Register[T any](s IStrategy[T]) {
+	funcs := make(map[Kind]func(v Variant) (T, error))
+	for k := range Kind(len(kindNames)) {
+		if fn := s.Get(k); fn != nil {
+			funcs[k] = fn
+		}
+	}
+	register(funcs)
+}
+
+// RegisterFunc installs fn as the conversion of variants of kind k to T, see
+// Register.
+func RegisterFunc[T any](k Kind, fn func(v Variant) (T, error)) {
+	register(map[Kind]func(v Variant) (T, error){k: fn})
+}
+
+// Unregister removes every conversion registered for T, restoring the
+// built-in strategies if there are any.
+func Unregister[T any]() {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	m := cloneRegistry()
+	delete(m, reflect.TypeFor[T]())
+	registry.m.Store(&m)
+}
+
+func register[T any](funcs map[Kind]func(v Variant) (T, error)) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	m := cloneRegistry()
+	t := reflect.TypeFor[T]()
+	c := Converter[T]{m: make(map[Kind]func(v Variant) (T, error))}
+	if old, ok := m[t]; ok {
+		maps.Copy(c.m, old.(Converter[T]).m)
+	}
+	maps.Copy(c.m, funcs)
+	m[t] = c
+	registry.m.Store(&m)
+}
+
+// cloneRegistry returns a copy of the registered strategies, the caller must
+// hold registry.mu.
+func cloneRegistry() map[reflect.Type]any {
+	if old := registry.m.Load(); old != nil {
+		return maps.Clone(*old)
+	}
+	return make(map[reflect.Type]any)
+}
+
+// lookup returns the registered conversion of kind k to T, or nil.
+func lookup[T any](k Kind) func(v Variant) (T, error) {
+	m := registry.m.Load()
+	if m == nil {
+		return nil
+	}
+	if c, ok := (*m)[reflect.TypeFor[T]()]; ok {
+		return c.(Converter[T]).Get(k)
+	}
+	return nil
+}
+
+// lookupType is like lookup for a type only known at run time, e.g. the type
+// of a struct field Decode stores into.
+func lookupType(t reflect.Type, k Kind) func(v Variant) (any, error) {
+	m := registry.m.Load()
+	if m == nil {
+		return nil
+	}
+	if c, ok := (*m)[t]; ok {
+		return c.(anyConverter).getAny(k)
+	}
+	return nil
+}
+
+// anyConverter is implemented by every Converter[T], hiding T from lookupType.
+type anyConverter interface {
+	getAny(k Kind) func(v Variant) (any, error)
+}
+
+func (c Converter[T]) getAny(k Kind) func(v Variant) (any, error) {
+	fn := c.m[k]
+	if fn == nil {
+		return nil
+	}
+	return func(v Variant) (any, error) { return fn(v) }
+}
@@ -0,0 +1,112 @@
+package variant
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type Money struct {
+	Cents int64
+}
+
+type moneyConverter struct {
+	Converter[Money]
+}
+
+func newMoneyConverter() IStrategy[Money] {
+	c := &moneyConverter{}
+	c.m = map[Kind]func(v Variant) (Money, error){
+		String: func(v Variant) (Money, error) {
+			units, cents, _ := strings.Cut(v.ToString(), ".")
+			u, err := strconv.ParseInt(units, 10, 64)
+			if err != nil {
+				return Money{}, err
+			}
+			c, _ := strconv.ParseInt(cents, 10, 64)
+			return Money{Cents: u*100 + c}, nil
+		},
+		Int64: func(v Variant) (Money, error) {
+			return Money{Cents: v.ToInt64()}, nil
+		},
+	}
+	return c
+}
+
+func TestRegister(t *testing.T) {
+	defer Unregister[Money]()
+
+	_, err := TryTo[Money](New("12.34"))
+	assert(errors.Is(err, ErrUnsupported), err)
+
+	Register(newMoneyConverter())
+	assert(To[Money](New("12.34")) == Money{Cents: 1234})
+	assert(To[Money](New(int64(99))) == Money{Cents: 99})
+	_, err = TryTo[Money](New(1.5))
+	var e *ConversionError
+	assert(errors.As(err, &e) && e.To == Interface && errors.Is(err, ErrUnsupported), err)
+
+	RegisterFunc(Float64, func(v Variant) (Money, error) {
+		return Money{Cents: int64(v.ToFloat64() * 100)}, nil
+	})
+	assert(To[Money](New(1.5)) == Money{Cents: 150})
+	assert(To[Money](New("12.34")) == Money{Cents: 1234})
+}
+
+func TestRegister_Override(t *testing.T) {
+	defer Unregister[int]()
+
+	assert(New(true).ToInt() == 1)
+	RegisterFunc(Bool, func(v Variant) (int, error) {
+		if v.ToBool() {
+			return -1, nil
+		}
+		return 0, nil
+	})
+	assert(New(true).ToInt() == -1)
+	assert(To[int](New(true)) == -1)
+	assert(New("42").ToInt() == 42)
+
+	Unregister[int]()
+	assert(New(true).ToInt() == 1)
+}
+
+func TestRegister_OverrideBool(t *testing.T) {
+	defer Unregister[bool]()
+
+	assert(New("no").ToBool())
+	RegisterFunc(String, func(v Variant) (bool, error) {
+		return strconv.ParseBool(v.ToString())
+	})
+	assert(!New("no").ToBool() && !To[bool](New("no")))
+	assert(New("true").ToBool() && New(1).ToBool())
+	_, err := New("no").TryBool()
+	assert(err != nil)
+
+	Unregister[bool]()
+	assert(New("no").ToBool())
+}
+
+func TestRegister_Concurrent(t *testing.T) {
+	defer Unregister[Money]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			Register(newMoneyConverter())
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m, err := TryTo[Money](New(int64(j)))
+				assert(err != nil || m.Cents == int64(j))
+			}
+		}()
+	}
+	wg.Wait()
+	assert(To[Money](New(int64(7))) == Money{Cents: 7})
+}
@@ -0,0 +1,119 @@
+package variant
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	_ sql.Scanner   = (*Variant)(nil)
+	_ driver.Valuer = Variant{}
+)
+
+// Scan implements the sql.Scanner interface. It accepts the values a driver
+// returns: int64, float64, bool, []byte, string, time.Time and nil, which
+// becomes a null of the kind v already has, if any. As many drivers return
+// text and numeric columns as []byte, a []byte holding valid UTF-8 becomes a
+// String, which ToBytes returns unchanged, unless v already is a Bytes
+// variant. Other []byte values become Bytes.
+func (v *Variant) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*v = NewNull(v.Type)
+		return nil
+	case []byte:
+		// both copy src, which the driver may reuse after Scan returns
+		if v.Type != Bytes && utf8.Valid(src) {
+			*v = New(string(src))
+			return nil
+		}
+		*v = New(src)
+		return nil
+	case int64, float64, bool, string, time.Time:
+		*v = New(src)
+		return nil
+	}
+	return fmt.Errorf("variant: cannot scan %T into a Variant", src)
+}
+
+// Value implements the driver.Valuer interface. Integers become int64,
+// floats float64, and numbers that do not fit either, like a BigInt or a
+// Decimal, become their exact string form. Lists and maps become JSON text.
+// Invalid variants and typed nulls are NULL.
+func (v Variant) Value() (driver.Value, error) {
+	if v.null {
+		return nil, nil
+	}
+	switch v.Type {
+	case Invalid:
+		return nil, nil
+	case Bool:
+		return v.TryBool()
+	case Int, Int8, Int16, Int32, Int64, Duration:
+		return v.TryInt64()
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		u, err := v.TryUint64()
+		if err != nil {
+			return nil, err
+		}
+		if u > math.MaxInt64 {
+			return nil, conversionError(v, Int64, ErrOverflow)
+		}
+		return int64(u), nil
+	case Float32, Float64:
+		return v.TryFloat64()
+	case String, Complex64, Complex128, BigInt, BigFloat, Decimal:
+		return v.canonical().TryString()
+	case Bytes:
+		return v.TryBytes()
+	case Time:
+		return v.TryTime()
+	case List, Map:
+		b, err := v.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	return nil, conversionError(v, Interface, ErrUnsupported)
+}
+
+// ScanRow scans the current row of rows into a slice holding a variant for
+// each column.
+func ScanRow(rows *sql.Rows) ([]Variant, error) {
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	row := make([]Variant, len(cols))
+	dest := make([]any, len(cols))
+	for i := range row {
+		dest[i] = &row[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// ScanMap scans the current row of rows into a map of variants keyed by
+// column name.
+func ScanMap(rows *sql.Rows) (map[string]Variant, error) {
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	row, err := ScanRow(rows)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]Variant, len(cols))
+	for i, col := range cols {
+		m[col] = row[i]
+	}
+	return m, nil
+}
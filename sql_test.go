@@ -0,0 +1,145 @@
+package variant
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// fakeDriver serves a single table of fakeRows and records the arguments of
+// the statements it executes in fakeArgs.
+type fakeDriver struct{}
+
+var (
+	fakeColumns = []string{"id", "name", "score", "active", "avatar", "created", "note", "total"}
+	fakeRows    = [][]driver.Value{
+		// text and numeric columns as []byte, like the MySQL text protocol
+		{int64(1), []byte("ann"), 9.5, true, []byte{0x89, 'P'}, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), nil, []byte("42")},
+		{int64(2), "bob", 7.25, false, []byte{}, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), "late", []byte("-7.50")},
+	}
+	fakeArgs []driver.Value
+)
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	fakeArgs = args
+	return driver.RowsAffected(1), nil
+}
+
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRowsIter{}, nil
+}
+
+type fakeRowsIter struct{ i int }
+
+func (r *fakeRowsIter) Columns() []string { return fakeColumns }
+func (r *fakeRowsIter) Close() error      { return nil }
+
+func (r *fakeRowsIter) Next(dest []driver.Value) error {
+	if r.i == len(fakeRows) {
+		return io.EOF
+	}
+	copy(dest, fakeRows[r.i])
+	r.i++
+	return nil
+}
+
+func init() {
+	sql.Register("variant-fake", fakeDriver{})
+}
+
+func TestVariant_Scan(t *testing.T) {
+	db, err := sql.Open("variant-fake", "")
+	assert(err == nil, err)
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM users")
+	assert(err == nil, err)
+	defer rows.Close()
+
+	assert(rows.Next())
+	m, err := ScanMap(rows)
+	assert(err == nil, err)
+	assert(m["id"].Type == Int64 && m["id"].ToInt() == 1)
+	assert(m["name"].ToString() == "ann")
+	assert(m["score"].ToFloat64() == 9.5)
+	assert(m["active"].ToBool())
+	assert(m["avatar"].Type == Bytes && string(m["avatar"].ToBytes()) == "\x89P")
+	assert(m["created"].ToTime().Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)))
+	assert(m["note"].Type == Invalid)
+	assert(m["name"].Type == String && string(m["name"].ToBytes()) == "ann")
+	total, err := m["total"].TryInt64()
+	assert(err == nil && total == 42, total, err)
+
+	assert(rows.Next())
+	row, err := ScanRow(rows)
+	assert(err == nil, err)
+	assert(len(row) == len(fakeColumns))
+	assert(row[1].ToString() == "bob" && row[6].ToString() == "late")
+	assert(row[7].ToDecimal().String() == "-7.50")
+	assert(!rows.Next())
+
+	var v Variant
+	assert(v.Scan(int64(5)) == nil && v.ToInt() == 5)
+	assert(v.Scan(struct{}{}) != nil)
+	v = NewNull(Bytes)
+	assert(v.Scan([]byte("ann")) == nil && v.Type == Bytes && v.ToString() == "YW5u")
+}
+
+func TestVariant_Value(t *testing.T) {
+	targets := []Pair[driver.Value]{
+		{nil, nil},
+		{true, true},
+		{int8(-8), int64(-8)},
+		{uint32(7), int64(7)},
+		{float32(1.5), 1.5},
+		{"abc", "abc"},
+		{[]byte{1, 2}, []byte{1, 2}},
+		{complex(1, 2), "1+2i"},
+		{time.Second, int64(time.Second)},
+		{big.NewInt(12), "12"},
+		{NewDec(1999, 2), "19.99"},
+		{[]any{1, "a"}, `[1,"a"]`},
+	}
+	for _, pair := range targets {
+		t.Run("Value", func(t *testing.T) {
+			got, err := New(pair.Key).Value()
+			assert(err == nil, pair.Key, err)
+			if b, ok := got.([]byte); ok {
+				assert(string(b) == string(pair.Val.([]byte)), pair.Key, got)
+				return
+			}
+			assert(got == pair.Val, pair.Key, got)
+		})
+	}
+	tm := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	got, _ := New(tm).Value()
+	assert(got.(time.Time).Equal(tm))
+
+	_, err := New(uint64(math.MaxUint64)).Value()
+	assert(errors.Is(err, ErrOverflow), err)
+
+	db, err := sql.Open("variant-fake", "")
+	assert(err == nil, err)
+	defer db.Close()
+	_, err = db.Exec("INSERT INTO users VALUES (?, ?, ?)", New(int16(3)), New("cid"), Nil)
+	assert(err == nil, err)
+	assert(len(fakeArgs) == 3 && fakeArgs[0] == int64(3) && fakeArgs[1] == "cid" && fakeArgs[2] == nil, fakeArgs)
+}
@@ -1,10 +1,7 @@
 package variant
 
 import (
-	"encoding/binary"
-	"math"
 	"strconv"
-	"time"
 	"unsafe"
 )
 
@@ -14,128 +11,215 @@ type stringConverter struct {
 	Converter[string]
 }
 
-func (c stringConverter) FromString(v Variant) string {
-	return *(*string)(unsafe.Pointer(&v.Data))
+func (c stringConverter) FromString(v Variant) (string, error) {
+	return *(*string)(unsafe.Pointer(&v.Data)), nil
 }
 
-func (c stringConverter) FromBool(v Variant) string {
+func (c stringConverter) FromBool(v Variant) (string, error) {
 	if len(v.Data) == 0 || v.Data[0] == 0x00 {
-		return "false"
+		return "false", nil
 	}
-	return "true"
+	return "true", nil
 }
 
-func (c stringConverter) FromInt(v Variant) string {
-	var i int
-	if len(v.Data) == 4 && intSize == 32 {
-		i = int(binary.BigEndian.Uint32(v.Data))
-	} else if len(v.Data) == 8 && intSize == 64 {
-		i = int(binary.BigEndian.Uint64(v.Data))
+func (c stringConverter) FromInt(v Variant) (string, error) {
+	i, err := payloadInt(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
+	}
+	return v.localize(strconv.FormatInt(i, 10)), nil
+}
+
+func (c stringConverter) FromInt8(v Variant) (string, error) {
+	i, err := payloadInt8(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
+	}
+	return v.localize(strconv.FormatInt(int64(i), 10)), nil
+}
+
+func (c stringConverter) FromInt16(v Variant) (string, error) {
+	i, err := payloadInt16(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
+	}
+	return v.localize(strconv.FormatInt(int64(i), 10)), nil
+}
+
+func (c stringConverter) FromInt32(v Variant) (string, error) {
+	i, err := payloadInt32(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
+	}
+	return v.localize(strconv.FormatInt(int64(i), 10)), nil
+}
+
+func (c stringConverter) FromInt64(v Variant) (string, error) {
+	i, err := payloadInt64(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
+	}
+	return v.localize(strconv.FormatInt(i, 10)), nil
+}
+
+func (c stringConverter) FromUint(v Variant) (string, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
+	}
+	return v.localize(strconv.FormatUint(i, 10)), nil
+}
+
+func (c stringConverter) FromUint8(v Variant) (string, error) {
+	i, err := payloadUint8(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
 	}
-	return strconv.Itoa(i)
+	return v.localize(strconv.FormatUint(uint64(i), 10)), nil
 }
 
-func (c stringConverter) FromInt8(v Variant) string {
-	var i int8
-	if len(v.Data) == 1 {
-		i = int8(v.Data[0])
+func (c stringConverter) FromUint16(v Variant) (string, error) {
+	i, err := payloadUint16(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
 	}
-	b := strconv.AppendInt(make([]byte, 0), int64(i), 10)
-	return *(*string)(unsafe.Pointer(&b))
+	return v.localize(strconv.FormatUint(uint64(i), 10)), nil
 }
 
-func (c stringConverter) FromInt16(v Variant) string {
-	var i int16
-	if len(v.Data) == 2 {
-		i = int16(binary.BigEndian.Uint16(v.Data))
+func (c stringConverter) FromUint32(v Variant) (string, error) {
+	i, err := payloadUint32(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
 	}
-	return strconv.Itoa(int(i))
+	return v.localize(strconv.FormatUint(uint64(i), 10)), nil
 }
 
-func (c stringConverter) FromInt32(v Variant) string {
-	i := int32(binary.BigEndian.Uint32(v.Data))
-	return strconv.Itoa(int(i))
+func (c stringConverter) FromUint64(v Variant) (string, error) {
+	i, err := payloadUint64(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
+	}
+	return v.localize(strconv.FormatUint(i, 10)), nil
 }
 
-func (c stringConverter) FromInt64(v Variant) string {
-	i := int64(binary.BigEndian.Uint64(v.Data))
-	return strconv.Itoa(int(i))
+func (c stringConverter) FromUintptr(v Variant) (string, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
+	}
+	return v.localize(strconv.FormatUint(i, 10)), nil
 }
 
-func (c stringConverter) FromUint(v Variant) string {
-	var i uint64
-	if intSize == 32 {
-		i = uint64(binary.BigEndian.Uint32(v.Data))
-	} else if intSize == 64 {
-		i = binary.BigEndian.Uint64(v.Data)
+func (c stringConverter) FromFloat32(v Variant) (string, error) {
+	f, err := payloadFloat32(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
 	}
-	return strconv.FormatUint(i, 10)
+	return v.localize(strconv.FormatFloat(float64(f), 'f', -1, 32)), nil
 }
 
-func (c stringConverter) FromUint8(v Variant) string {
-	i := v.Data[0]
-	b := strconv.AppendUint(make([]byte, 0), uint64(i), 10)
-	return *(*string)(unsafe.Pointer(&b))
+func (c stringConverter) FromFloat64(v Variant) (string, error) {
+	f, err := payloadFloat64(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
+	}
+	return v.localize(strconv.FormatFloat(f, 'f', -1, 64)), nil
 }
 
-func (c stringConverter) FromUint16(v Variant) string {
-	i := uint64(binary.BigEndian.Uint16(v.Data))
-	return strconv.FormatUint(i, 10)
+func (c stringConverter) FromComplex64(v Variant) (string, error) {
+	z, err := payloadComplex64(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
+	}
+	return formatComplex(complex128(z), 64), nil
 }
 
-func (c stringConverter) FromUint32(v Variant) string {
-	i := uint64(binary.BigEndian.Uint32(v.Data))
-	return strconv.FormatUint(i, 10)
+func (c stringConverter) FromComplex128(v Variant) (string, error) {
+	z, err := payloadComplex128(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
+	}
+	return formatComplex(complex128(z), 128), nil
 }
 
-func (c stringConverter) FromUint64(v Variant) string {
-	i := binary.BigEndian.Uint64(v.Data)
-	return strconv.FormatUint(i, 10)
+func (c stringConverter) FromTime(v Variant) (string, error) {
+	t, err := payloadTime(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
+	}
+	return v.inLocation(t).Format(v.layout), nil
+}
+
+func (c stringConverter) FromDuration(v Variant) (string, error) {
+	d, err := payloadDuration(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
+	}
+	return d.String(), nil
 }
 
-func (c stringConverter) FromFloat32(v Variant) string {
-	var f float32
-	if len(v.Data) == 4 {
-		f = math.Float32frombits(binary.BigEndian.Uint32(v.Data))
+func (c stringConverter) FromBigInt(v Variant) (string, error) {
+	b, err := payloadBigInt(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
 	}
-	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+	return v.localize(b.String()), nil
 }
 
-func (c stringConverter) FromFloat64(v Variant) string {
-	var f float64
-	if len(v.Data) == 8 {
-		f = math.Float64frombits(binary.BigEndian.Uint64(v.Data))
+func (c stringConverter) FromBigFloat(v Variant) (string, error) {
+	f, err := payloadBigFloat(v)
+	if err != nil {
+		return "", conversionError(v, String, err)
 	}
-	return strconv.FormatFloat(f, 'f', -1, 64)
+	return v.localize(f.Text('g', -1)), nil
 }
 
-func (c stringConverter) FromTime(v Variant) string {
-	var t time.Time
-	err := t.UnmarshalBinary(v.Data)
+func (c stringConverter) FromDecimal(v Variant) (string, error) {
+	d, err := payloadDec(v)
 	if err != nil {
-		return ""
+		return "", conversionError(v, String, err)
 	}
-	return t.Format(v.layout)
+	return v.localize(d.String()), nil
+}
+
+// FromBytes encodes the payload with BytesEncoding.
+func (c stringConverter) FromBytes(v Variant) (string, error) {
+	return BytesEncoding.EncodeToString(v.Data), nil
 }
 
 func newStringConverter() IConvertStrategy[string] {
 	c := &stringConverter{}
-	c.m = map[Kind]func(v Variant) string{
-		String:  c.FromString,
-		Bool:    c.FromBool,
-		Int:     c.FromInt,
-		Int8:    c.FromInt8,
-		Int16:   c.FromInt16,
-		Int32:   c.FromInt32,
-		Int64:   c.FromInt64,
-		Uint:    c.FromUint,
-		Uint8:   c.FromUint8,
-		Uint16:  c.FromUint16,
-		Uint32:  c.FromUint32,
-		Uint64:  c.FromUint64,
-		Float32: c.FromFloat32,
-		Float64: c.FromFloat64,
-		Time:    c.FromTime,
+	c.m = map[Kind]func(v Variant) (string, error){
+		String:     c.FromString,
+		Bool:       c.FromBool,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Time:       c.FromTime,
+		Duration:   c.FromDuration,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
+		Bytes:      c.FromBytes,
 	}
 	return c
 }
+
+// formatComplex formats z like strconv.FormatComplex without the enclosing
+// parentheses, e.g. "1+2i".
+func formatComplex(z complex128, bitSize int) string {
+	s := strconv.FormatComplex(z, 'f', -1, bitSize)
+	return s[1 : len(s)-1]
+}
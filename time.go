@@ -1,7 +1,6 @@
 package variant
 
 import (
-	"encoding/binary"
 	"math"
 	"strconv"
 	"time"
@@ -15,141 +14,273 @@ type timeConverter struct {
 }
 
 // FromBool implements IConvertStrategy.
-func (t *timeConverter) FromBool(v Variant) time.Time {
+func (t *timeConverter) FromBool(v Variant) (time.Time, error) {
 	if len(v.Data) == 0 || v.Data[0] == 0x00 {
-		return time.Time{}
+		return time.Time{}, nil
 	}
-	return time.Now()
+	return time.Now(), nil
 }
 
 // FromFloat32 implements IConvertStrategy.
-func (t *timeConverter) FromFloat32(v Variant) time.Time {
-	var f float32
-	if len(v.Data) == 4 {
-		f = math.Float32frombits(binary.BigEndian.Uint32(v.Data))
+func (t *timeConverter) FromFloat32(v Variant) (time.Time, error) {
+	f, err := payloadFloat32(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
 	}
-	return time.Unix(0, int64(f))
+	return t.fromEpochFloat(v, float64(f))
 }
 
 // FromFloat64 implements IConvertStrategy.
-func (t *timeConverter) FromFloat64(v Variant) time.Time {
-	var f float64
-	if len(v.Data) == 8 {
-		f = math.Float64frombits(binary.BigEndian.Uint64(v.Data))
+func (t *timeConverter) FromFloat64(v Variant) (time.Time, error) {
+	f, err := payloadFloat64(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
 	}
-	return time.Unix(0, int64(f))
+	return t.fromEpochFloat(v, float64(f))
 }
 
 // FromInt implements IConvertStrategy.
-func (t *timeConverter) FromInt(v Variant) time.Time {
-	var i int
-	if len(v.Data) == 4 && intSize == 32 {
-		i = int(binary.BigEndian.Uint32(v.Data))
-	} else if len(v.Data) == 8 && intSize == 64 {
-		i = int(binary.BigEndian.Uint64(v.Data))
+func (t *timeConverter) FromInt(v Variant) (time.Time, error) {
+	i, err := payloadInt(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
 	}
-	return time.Unix(0, int64(i))
+	return t.fromEpoch(v, int64(i))
 }
 
 // FromInt16 implements IConvertStrategy.
-func (t *timeConverter) FromInt16(v Variant) time.Time {
-	var i int16
-	if len(v.Data) == 2 {
-		i = int16(binary.BigEndian.Uint16(v.Data))
+func (t *timeConverter) FromInt16(v Variant) (time.Time, error) {
+	i, err := payloadInt16(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
 	}
-	return time.Unix(0, int64(i))
+	return t.fromEpoch(v, int64(i))
 }
 
 // FromInt32 implements IConvertStrategy.
-func (t *timeConverter) FromInt32(v Variant) time.Time {
-	return time.Unix(0, int64(binary.BigEndian.Uint32(v.Data)))
+func (t *timeConverter) FromInt32(v Variant) (time.Time, error) {
+	i, err := payloadInt32(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	return t.fromEpoch(v, int64(i))
 }
 
 // FromInt64 implements IConvertStrategy.
-func (t *timeConverter) FromInt64(v Variant) time.Time {
-	return time.Unix(0, int64(binary.BigEndian.Uint64(v.Data)))
+func (t *timeConverter) FromInt64(v Variant) (time.Time, error) {
+	i, err := payloadInt64(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	return t.fromEpoch(v, int64(i))
 }
 
 // FromInt8 implements IConvertStrategy.
-func (t *timeConverter) FromInt8(v Variant) time.Time {
-	var i int8
-	if len(v.Data) == 1 {
-		i = int8(v.Data[0])
+func (t *timeConverter) FromInt8(v Variant) (time.Time, error) {
+	i, err := payloadInt8(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
 	}
-	return time.Unix(0, int64(i))
+	return t.fromEpoch(v, int64(i))
 }
 
 // FromString implements IConvertStrategy.
-func (t *timeConverter) FromString(v Variant) time.Time {
+func (t *timeConverter) FromString(v Variant) (time.Time, error) {
 	s := *(*string)(unsafe.Pointer(&v.Data))
-	tt, err := time.Parse(v.layout, s)
+	o := v.options()
+	var tt time.Time
+	var err error
+	if o.Location != nil {
+		tt, err = time.ParseInLocation(v.layout, s, o.Location)
+	} else {
+		tt, err = time.Parse(v.layout, s)
+	}
 	if err != nil {
-		if i, e := strconv.Atoi(s); e == nil {
-			return time.Unix(0, int64(i))
+		if i, e := strconv.ParseInt(s, 10, 64); e == nil && !o.Strict {
+			return t.fromEpoch(v, i)
 		}
+		return tt, conversionError(v, Time, ErrSyntax)
 	}
-	return tt
+	return v.inLocation(tt), nil
 }
 
 // FromTime implements IConvertStrategy.
-func (t *timeConverter) FromTime(v Variant) time.Time {
-	var tt time.Time
-	err := tt.UnmarshalBinary(v.Data)
+func (t *timeConverter) FromTime(v Variant) (time.Time, error) {
+	tt, err := payloadTime(v)
 	if err != nil {
-		return time.Time{}
+		return time.Time{}, conversionError(v, Time, err)
 	}
-	return tt
+	return v.inLocation(tt), nil
 }
 
 // FromUint implements IConvertStrategy.
-func (t *timeConverter) FromUint(v Variant) time.Time {
-	var i uint
-	if intSize == 32 {
-		i = uint(binary.BigEndian.Uint32(v.Data))
-	} else if intSize == 64 {
-		i = uint(binary.BigEndian.Uint64(v.Data))
+func (t *timeConverter) FromUint(v Variant) (time.Time, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	if uint64(i) > math.MaxInt64 {
+		return time.Time{}, conversionError(v, Time, ErrOverflow)
 	}
-	return time.Unix(0, int64(i))
+	return t.fromEpoch(v, int64(i))
 }
 
 // FromUint16 implements IConvertStrategy.
-func (t *timeConverter) FromUint16(v Variant) time.Time {
-	return time.Unix(0, int64(binary.BigEndian.Uint16(v.Data)))
+func (t *timeConverter) FromUint16(v Variant) (time.Time, error) {
+	i, err := payloadUint16(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	return t.fromEpoch(v, int64(i))
 }
 
 // FromUint32 implements IConvertStrategy.
-func (t *timeConverter) FromUint32(v Variant) time.Time {
-	return time.Unix(0, int64(binary.BigEndian.Uint32(v.Data)))
+func (t *timeConverter) FromUint32(v Variant) (time.Time, error) {
+	i, err := payloadUint32(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	return t.fromEpoch(v, int64(i))
 }
 
 // FromUint64 implements IConvertStrategy.
-func (t *timeConverter) FromUint64(v Variant) time.Time {
-	return time.Unix(0, int64(binary.BigEndian.Uint64(v.Data)))
+func (t *timeConverter) FromUint64(v Variant) (time.Time, error) {
+	i, err := payloadUint64(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	if uint64(i) > math.MaxInt64 {
+		return time.Time{}, conversionError(v, Time, ErrOverflow)
+	}
+	return t.fromEpoch(v, int64(i))
 }
 
 // FromUint8 implements IConvertStrategy.
-func (t *timeConverter) FromUint8(v Variant) time.Time {
-	return time.Unix(0, int64(v.Data[0]))
+func (t *timeConverter) FromUint8(v Variant) (time.Time, error) {
+	i, err := payloadUint8(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	return t.fromEpoch(v, int64(i))
+}
+
+// FromUintptr implements IConvertStrategy.
+func (t *timeConverter) FromUintptr(v Variant) (time.Time, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	if uint64(i) > math.MaxInt64 {
+		return time.Time{}, conversionError(v, Time, ErrOverflow)
+	}
+	return t.fromEpoch(v, int64(i))
+}
+
+// FromComplex64 implements IConvertStrategy.
+func (t *timeConverter) FromComplex64(v Variant) (time.Time, error) {
+	z, err := payloadComplex64(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	f := real(z)
+	return t.fromEpochFloat(v, float64(f))
+}
+
+// FromComplex128 implements IConvertStrategy.
+func (t *timeConverter) FromComplex128(v Variant) (time.Time, error) {
+	z, err := payloadComplex128(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	f := real(z)
+	return t.fromEpochFloat(v, float64(f))
+}
+
+// FromDuration implements IConvertStrategy. A duration is not a point in
+// time, so the conversion is not supported.
+func (t *timeConverter) FromDuration(v Variant) (time.Time, error) {
+	return time.Time{}, conversionError(v, Time, ErrUnsupported)
+}
+
+// FromBigInt implements IConvertStrategy.
+func (t *timeConverter) FromBigInt(v Variant) (time.Time, error) {
+	b, err := payloadBigInt(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	if !b.IsInt64() {
+		return time.Time{}, conversionError(v, Time, ErrOverflow)
+	}
+	return t.fromEpoch(v, b.Int64())
+}
+
+// FromBigFloat implements IConvertStrategy.
+func (t *timeConverter) FromBigFloat(v Variant) (time.Time, error) {
+	d, err := decimalConverter{}.FromBigFloat(v)
+	if err != nil {
+		return time.Time{}, retarget(err, Time)
+	}
+	return t.fromEpochDec(v, d)
+}
+
+// FromDecimal implements IConvertStrategy.
+func (t *timeConverter) FromDecimal(v Variant) (time.Time, error) {
+	d, err := payloadDec(v)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	return t.fromEpochDec(v, d)
+}
+
+// fromEpoch returns the time n EpochUnit after the Unix epoch.
+func (t *timeConverter) fromEpoch(v Variant, n int64) (time.Time, error) {
+	tt, err := epochTime(v, n)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	return tt, nil
+}
+
+func (t *timeConverter) fromEpochFloat(v Variant, f float64) (time.Time, error) {
+	tt, err := epochTimeFloat(v, f)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	return tt, nil
+}
+
+func (t *timeConverter) fromEpochDec(v Variant, d Dec) (time.Time, error) {
+	tt, err := epochTimeDec(v, d)
+	if err != nil {
+		return time.Time{}, conversionError(v, Time, err)
+	}
+	return tt, nil
 }
 
 func newTimeConverter() IConvertStrategy[time.Time] {
 	c := &timeConverter{}
-	c.m = map[Kind]func(v Variant) time.Time{
-		String:  c.FromString,
-		Bool:    c.FromBool,
-		Int:     c.FromInt,
-		Int8:    c.FromInt8,
-		Int16:   c.FromInt16,
-		Int32:   c.FromInt32,
-		Int64:   c.FromInt64,
-		Uint:    c.FromUint,
-		Uint8:   c.FromUint8,
-		Uint16:  c.FromUint16,
-		Uint32:  c.FromUint32,
-		Uint64:  c.FromUint64,
-		Float32: c.FromFloat32,
-		Float64: c.FromFloat64,
-		Time:    c.FromTime,
+	c.m = map[Kind]func(v Variant) (time.Time, error){
+		String:     c.FromString,
+		Bool:       c.FromBool,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Time:       c.FromTime,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
 	}
 	return c
 }
@@ -0,0 +1,150 @@
+package variant
+
+import (
+	"math/big"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// To converts the Variant to T and returns the zero value of T when the
+// conversion fails. T may be any Go primitive type, time.Time, time.Duration,
+// []byte, a named type whose underlying type is one of those, or a type with
+// a registered strategy (see Register).
+func To[T any](v Variant) T {
+	r, _ := TryTo[T](v)
+	return r
+}
+
+// TryTo is like To but reports a *ConversionError when the conversion fails.
+func TryTo[T any](v Variant) (T, error) {
+	if v.null {
+		var zero T
+		return zero, conversionError(v, Interface, ErrNull)
+	}
+	if fn := lookup[T](v.Type); fn != nil {
+		return fn(v)
+	}
+
+	var r T
+	err := assign(v, &r)
+	return r, err
+}
+
+// assign converts v to the type p points to and stores the result in *p.
+func assign(v Variant, p any) error {
+	var err error
+	switch p := p.(type) {
+	case *bool:
+		*p, err = v.TryBool()
+	case *string:
+		*p, err = v.TryString()
+	case *int:
+		*p, err = v.TryInt()
+	case *int8:
+		*p, err = v.TryInt8()
+	case *int16:
+		*p, err = v.TryInt16()
+	case *int32:
+		*p, err = v.TryInt32()
+	case *int64:
+		*p, err = v.TryInt64()
+	case *uint:
+		*p, err = v.TryUint()
+	case *uint8:
+		*p, err = v.TryUint8()
+	case *uint16:
+		*p, err = v.TryUint16()
+	case *uint32:
+		*p, err = v.TryUint32()
+	case *uint64:
+		*p, err = v.TryUint64()
+	case *uintptr:
+		*p, err = v.TryUintptr()
+	case *float32:
+		*p, err = v.TryFloat32()
+	case *float64:
+		*p, err = v.TryFloat64()
+	case *complex64:
+		*p, err = v.TryComplex64()
+	case *complex128:
+		*p, err = v.TryComplex128()
+	case *time.Time:
+		*p, err = v.TryTime()
+	case *time.Duration:
+		*p, err = v.TryDuration()
+	case **big.Int:
+		*p, err = v.TryBigInt()
+	case **big.Float:
+		*p, err = v.TryBigFloat()
+	case *Dec:
+		*p, err = v.TryDecimal()
+	case *[]byte:
+		*p, err = v.TryBytes()
+	default:
+		err = convertValue(v, reflect.ValueOf(p).Elem())
+	}
+	return err
+}
+
+// convertValue converts v to the type of dst, which must be settable, based on
+// the kind of that type. It handles the types whose underlying type is not one
+// of the types handled by the type switch of TryTo.
+func convertValue(v Variant, dst reflect.Value) error {
+	var x any
+	var err error
+	t := dst.Type()
+	switch t.Kind() {
+	case reflect.Bool:
+		x, err = v.TryBool()
+	case reflect.Int:
+		x, err = v.TryInt()
+	case reflect.Int8:
+		x, err = v.TryInt8()
+	case reflect.Int16:
+		x, err = v.TryInt16()
+	case reflect.Int32:
+		x, err = v.TryInt32()
+	case reflect.Int64:
+		x, err = v.TryInt64()
+	case reflect.Uint:
+		x, err = v.TryUint()
+	case reflect.Uint8:
+		x, err = v.TryUint8()
+	case reflect.Uint16:
+		x, err = v.TryUint16()
+	case reflect.Uint32:
+		x, err = v.TryUint32()
+	case reflect.Uint64:
+		x, err = v.TryUint64()
+	case reflect.Uintptr:
+		x, err = v.TryUintptr()
+	case reflect.Float32:
+		x, err = v.TryFloat32()
+	case reflect.Float64:
+		x, err = v.TryFloat64()
+	case reflect.Complex64:
+		x, err = v.TryComplex64()
+	case reflect.Complex128:
+		x, err = v.TryComplex128()
+	case reflect.String:
+		x, err = v.TryString()
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			x, err = v.TryBytes()
+		}
+	case reflect.Struct:
+		if timeType.ConvertibleTo(t) {
+			x, err = v.TryTime()
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if x == nil || !reflect.TypeOf(x).ConvertibleTo(t) {
+		return conversionError(v, Interface, ErrUnsupported)
+	}
+	dst.Set(reflect.ValueOf(x).Convert(t))
+	return nil
+}
@@ -0,0 +1,95 @@
+package variant
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+type Status int
+
+type Label string
+
+type Raw []byte
+
+type Timestamp time.Time
+
+func TestTo(t *testing.T) {
+	tt := time.Now()
+	v := New("42")
+	assert(To[bool](v))
+	assert(To[string](v) == "42")
+	assert(To[int](v) == 42)
+	assert(To[int8](v) == 42)
+	assert(To[int16](v) == 42)
+	assert(To[int32](v) == 42)
+	assert(To[int64](v) == 42)
+	assert(To[uint](v) == 42)
+	assert(To[uint8](v) == 42)
+	assert(To[uint16](v) == 42)
+	assert(To[uint32](v) == 42)
+	assert(To[uint64](v) == 42)
+	assert(To[uintptr](v) == 42)
+	assert(To[float32](v) == 42)
+	assert(To[float64](v) == 42)
+	assert(To[complex64](v) == 42)
+	assert(To[complex128](v) == 42)
+	assert(To[time.Duration](v) == 42)
+	assert(string(To[[]byte](v)) == "42")
+	assert(To[time.Time](New(tt)).Equal(tt))
+
+	assert(To[Status](v) == Status(42))
+	assert(To[Label](New(7)) == Label("7"))
+	assert(string(To[Raw](v)) == "42")
+	assert(time.Time(To[Timestamp](New(tt))).Equal(tt))
+}
+
+func TestTryTo(t *testing.T) {
+	targets := []Pair[error]{
+		{math.MaxInt8 + 1, ErrOverflow},
+		{math.MinInt8, nil},
+		{"x", ErrSyntax},
+		{uint16(math.MaxUint16), ErrOverflow},
+	}
+	for _, pair := range targets {
+		t.Run("TryTo", func(t *testing.T) {
+			_, err := TryTo[int8](New(pair.Key))
+			assert(errors.Is(err, pair.Val), pair.Key, err)
+		})
+	}
+
+	_, err := TryTo[uint8](New(256))
+	var e *ConversionError
+	assert(errors.As(err, &e) && e.To == Uint8 && errors.Is(err, ErrOverflow), err)
+	_, err = TryTo[int16](New("x"))
+	assert(errors.As(err, &e) && e.To == Int16 && errors.Is(err, ErrSyntax), err)
+	_, err = TryTo[Status](New("x"))
+	assert(errors.Is(err, ErrSyntax), err)
+	_, err = TryTo[struct{}](New(1))
+	assert(errors.As(err, &e) && e.To == Interface && errors.Is(err, ErrUnsupported), err)
+	_, err = TryTo[[]int](New(1))
+	assert(errors.Is(err, ErrUnsupported), err)
+}
+
+func TestVariant_ToInt8(t *testing.T) {
+	targets := []Pair[int8]{
+		{true, 1},
+		{"-128", -128},
+		{"128", 0},
+		{math.MaxInt16, 0},
+		{float32(-12.5), -12},
+	}
+	for _, pair := range targets {
+		t.Run("ToInt8", func(t *testing.T) {
+			assert(New(pair.Key).ToInt8() == pair.Val)
+		})
+	}
+	assert(New(math.MaxInt16).ToInt16() == math.MaxInt16)
+	assert(New(math.MaxInt16+1).ToInt16() == 0)
+	assert(New(math.MinInt32).ToInt32() == math.MinInt32)
+	assert(New(uint8(math.MaxUint8)).ToUint8() == math.MaxUint8)
+	assert(New(-1).ToUint16() == 0)
+	assert(New(uint64(math.MaxUint32)).ToUint32() == math.MaxUint32)
+	assert(New(uint64(math.MaxUint32+1)).ToUint32() == 0)
+}
@@ -25,6 +25,13 @@ const (
 	Interface
 	String
 	Time
+	Duration
+	List
+	Map
+	BigInt
+	BigFloat
+	Decimal
+	Bytes
 )
 
 func (k Kind) String() string {
@@ -55,4 +62,11 @@ var kindNames = []string{
 	Interface:  "interface",
 	String:     "string",
 	Time:       "time.Time",
+	Duration:   "time.Duration",
+	List:       "list",
+	Map:        "map",
+	BigInt:     "big.Int",
+	BigFloat:   "big.Float",
+	Decimal:    "decimal",
+	Bytes:      "[]byte",
 }
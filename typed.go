@@ -0,0 +1,343 @@
+package variant
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// Typed marshals its variant as a self-describing JSON envelope, from which
+// UnmarshalJSON reconstructs the exact kind, payload and time layout:
+//
+//	{"t":"int16","v":5}
+//	{"t":"time.Time","v":"2024-05-01T10:00:00Z","l":"2006-01-02"}
+//	{"t":"list","v":[{"t":"string","v":"a"},{"t":"uint8","v":1}]}
+//
+// "t" is the name of the kind, "v" the value, null for a typed null, and "l"
+// the layout when it is not time.DateTime. Times are written in RFC 3339,
+// durations in nanoseconds, bytes in base64 and the elements of lists and
+// maps as envelopes. A BigFloat also records its precision in "p". A time
+// whose offset RFC 3339 cannot tell apart, e.g. a zone other than UTC with no
+// offset, or one with seconds, is written in UTC with its offset in seconds
+// in "z".
+type Typed struct {
+	Variant
+}
+
+type envelope struct {
+	T string          `json:"t"`
+	V json.RawMessage `json:"v,omitempty"`
+	L *string         `json:"l,omitempty"`
+	P uint            `json:"p,omitempty"`
+	Z *int            `json:"z,omitempty"`
+}
+
+// MarshalJSON implements the JSONMarshaler interface.
+func (t Typed) MarshalJSON() ([]byte, error) {
+	v := t.Variant
+	env := envelope{T: v.Type.String()}
+	if v.layout != time.DateTime {
+		env.L = &v.layout
+	}
+	if v.Type == Invalid {
+		return json.Marshal(env)
+	}
+	if v.null {
+		env.V = json.RawMessage("null")
+		return json.Marshal(env)
+	}
+
+	var val any
+	var err error
+	switch v.Type {
+	case Bool:
+		val, err = v.TryBool()
+	case Int, Int8, Int16, Int32, Int64:
+		val, err = v.TryInt64()
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		val, err = v.TryUint64()
+	case Float32, Float64:
+		val, err = typedFloat(v)
+	case Complex64, Complex128, String, Decimal:
+		val, err = v.canonical().TryString()
+	case Bytes:
+		val = base64.StdEncoding.EncodeToString(v.Data)
+	case Time:
+		var tm time.Time
+		tm, err = payloadTime(v)
+		val, env.Z = typedTime(v, tm)
+	case Duration:
+		val, err = payloadDuration(v)
+	case BigInt:
+		val, err = v.TryBigInt()
+	case BigFloat:
+		var f *big.Float
+		f, err = payloadBigFloat(v)
+		if err == nil {
+			val, env.P = f.Text('g', -1), f.Prec()
+		}
+	case List:
+		list := make([]Typed, len(v.list))
+		for i, elem := range v.list {
+			list[i] = Typed{elem}
+		}
+		val = list
+	case Map:
+		val, err = typedObject(v)
+	default:
+		err = conversionError(v, Interface, ErrUnsupported)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if env.V, err = json.Marshal(val); err != nil {
+		return nil, err
+	}
+	return json.Marshal(env)
+}
+
+// typedFloat returns the shortest representation of a float, as a number, or
+// as a string for NaN and infinities.
+func typedFloat(v Variant) (any, error) {
+	bitSize := 64
+	f, err := payloadFloat64(v)
+	if v.Type == Float32 {
+		var f32 float32
+		f32, err = payloadFloat32(v)
+		f, bitSize = float64(f32), 32
+	}
+	if err != nil {
+		return nil, conversionError(v, Float64, err)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'g', -1, bitSize), nil
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, bitSize)), nil
+}
+
+// typedTime returns tm, the value of v, in RFC 3339, along with its offset
+// when parsing the text back would not give the payload of v.
+func typedTime(v Variant, tm time.Time) (string, *int) {
+	s := tm.Format(time.RFC3339Nano)
+	back, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return s, nil
+	}
+	if data, err := back.MarshalBinary(); err == nil && bytes.Equal(data, v.Data) {
+		return s, nil
+	}
+	_, offset := tm.Zone()
+	return tm.UTC().Format(time.RFC3339Nano), &offset
+}
+
+// typedObject encodes the entries of a Map variant as envelopes, keeping their
+// order.
+func typedObject(v Variant) (json.RawMessage, error) {
+	buf := []byte{'{'}
+	for i, key := range v.Keys() {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		val, err := Typed{v.obj.m[key]}.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, k...)
+		buf = append(buf, ':')
+		buf = append(buf, val...)
+	}
+	return append(buf, '}'), nil
+}
+
+// UnmarshalJSON implements the JSONUnmarshaler interface.
+func (t *Typed) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.Variant = NewNull(Invalid)
+		return nil
+	}
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return err
+	}
+	k, ok := kindNamed(env.T)
+	if !ok {
+		return fmt.Errorf("variant: unknown kind %q", env.T)
+	}
+	v, err := decodeTyped(k, env)
+	if err != nil {
+		return fmt.Errorf("variant: invalid %v value %s: %w", k, env.V, err)
+	}
+	if env.L != nil {
+		v.layout = *env.L
+	}
+	t.Variant = v
+	return nil
+}
+
+func decodeTyped(k Kind, env envelope) (Variant, error) {
+	raw := env.V
+	if k == Invalid {
+		return NewNull(Invalid), nil
+	}
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return NewNull(k), nil
+	}
+
+	var s string
+	switch k {
+	case Float32, Float64, Complex64, Complex128, String, Decimal, Bytes, Time, BigFloat:
+		if raw[0] == '"' {
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return Nil, err
+			}
+			break
+		}
+		s = string(raw)
+	default:
+		s = string(raw)
+	}
+
+	switch k {
+	case Bool:
+		b, err := strconv.ParseBool(s)
+		return New(b), err
+	case Int:
+		i, err := strconv.ParseInt(s, 10, intSize)
+		return New(int(i)), err
+	case Int8:
+		i, err := strconv.ParseInt(s, 10, 8)
+		return New(int8(i)), err
+	case Int16:
+		i, err := strconv.ParseInt(s, 10, 16)
+		return New(int16(i)), err
+	case Int32:
+		i, err := strconv.ParseInt(s, 10, 32)
+		return New(int32(i)), err
+	case Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		return New(i), err
+	case Uint:
+		u, err := strconv.ParseUint(s, 10, intSize)
+		return New(uint(u)), err
+	case Uint8:
+		u, err := strconv.ParseUint(s, 10, 8)
+		return New(uint8(u)), err
+	case Uint16:
+		u, err := strconv.ParseUint(s, 10, 16)
+		return New(uint16(u)), err
+	case Uint32:
+		u, err := strconv.ParseUint(s, 10, 32)
+		return New(uint32(u)), err
+	case Uint64:
+		u, err := strconv.ParseUint(s, 10, 64)
+		return New(u), err
+	case Uintptr:
+		u, err := strconv.ParseUint(s, 10, intSize)
+		return New(uintptr(u)), err
+	case Float32:
+		f, err := strconv.ParseFloat(s, 32)
+		return New(float32(f)), err
+	case Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		return New(f), err
+	case Complex64:
+		z, err := strconv.ParseComplex(s, 64)
+		return New(complex64(z)), err
+	case Complex128:
+		z, err := strconv.ParseComplex(s, 128)
+		return New(z), err
+	case String:
+		return New(s), nil
+	case Bytes:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if b == nil {
+			b = []byte{}
+		}
+		return New(b), err
+	case Time:
+		tm, err := time.Parse(time.RFC3339Nano, s)
+		if env.Z != nil {
+			tm = tm.In(time.FixedZone("", *env.Z))
+		}
+		return New(tm), err
+	case Duration:
+		i, err := strconv.ParseInt(s, 10, 64)
+		return New(time.Duration(i)), err
+	case BigInt:
+		b, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return Nil, ErrSyntax
+		}
+		return New(b), nil
+	case BigFloat:
+		prec := env.P
+		if prec == 0 {
+			prec = bigFloatPrec(s)
+		}
+		f, _, err := big.ParseFloat(s, 10, prec, big.ToNearestEven)
+		return New(f), err
+	case Decimal:
+		d, err := ParseDec(s)
+		return New(d), err
+	case List:
+		var list []Typed
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return Nil, err
+		}
+		v := NewList()
+		v.list = make([]Variant, len(list))
+		for i, elem := range list {
+			v.list[i] = elem.Variant
+		}
+		return v, nil
+	case Map:
+		return decodeTypedObject(raw)
+	}
+	return Nil, ErrUnsupported
+}
+
+// decodeTypedObject decodes an object of envelopes into a Map variant, keeping
+// the order of its entries.
+func decodeTypedObject(data []byte) (Variant, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return Nil, err
+	}
+	if tok != json.Delim('{') {
+		return Nil, errors.New("expected an object")
+	}
+	m := NewMap()
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return Nil, err
+		}
+		var val Typed
+		if err := dec.Decode(&val); err != nil {
+			return Nil, err
+		}
+		m.obj.set(tok.(string), val.Variant)
+	}
+	return m, nil
+}
+
+// kindNamed returns the kind whose String method returns name.
+func kindNamed(name string) (Kind, bool) {
+	for k, n := range kindNames {
+		if n == name {
+			return Kind(k), true
+		}
+	}
+	return Invalid, false
+}
@@ -0,0 +1,91 @@
+package variant
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestTyped(t *testing.T) {
+	f, _, _ := big.ParseFloat("1.25", 10, 200, big.ToNearestEven)
+	tm := New(time.Date(2024, 5, 1, 10, 30, 0, 123, time.UTC))
+	tm.SetLayout("2006-01-02")
+	var null *int16
+	values := []Variant{
+		New(true),
+		New(-1),
+		New(int8(-8)),
+		New(int16(5)),
+		New(int32(-32)),
+		New(int64(math.MinInt64)),
+		New(uint(1)),
+		New(uint8(255)),
+		New(uint16(16)),
+		New(uint32(32)),
+		New(uint64(math.MaxUint64)),
+		New(uintptr(7)),
+		New(float32(0.1)),
+		New(1.5),
+		New(math.Inf(-1)),
+		New(complex64(complex(1, -2))),
+		New(complex(1.5, 2.5)),
+		New("héllo"),
+		New([]byte{0, 1, 2}),
+		tm,
+		New(time.Date(2024, 5, 1, 10, 30, 0, 0, time.FixedZone("Z0", 0))),
+		New(time.Date(1850, 1, 1, 0, 0, 0, 0, time.FixedZone("LMT", 561))),
+		New(time.Date(2024, 5, 1, 10, 30, 0, 0, time.FixedZone("", -7*3600))),
+		New(90 * time.Second),
+		New(new(big.Int).Lsh(big.NewInt(1), 100)),
+		New(f),
+		New(NewDec(1990, 3)),
+		New(null),
+		Nil,
+		New([]any{1, "a", []any{}}),
+	}
+	m := NewMap()
+	m.Set("z", 1).Set("a", New(map[string]any{"k": int8(1)})).Set("n", null)
+	values = append(values, m)
+	for _, v := range values {
+		t.Run(v.Type.String(), func(t *testing.T) {
+			data, err := json.Marshal(Typed{v})
+			assert(err == nil, v, err)
+			var got Typed
+			assert(json.Unmarshal(data, &got) == nil, string(data))
+			assert(equal(got.Variant, v), string(data), got.Variant, v)
+			assert(bytes.Equal(got.Data, v.Data) || v.Type == BigFloat, string(data))
+		})
+	}
+
+	data, _ := json.Marshal(Typed{New(int16(5))})
+	assert(string(data) == `{"t":"int16","v":5}`, string(data))
+	data, _ = json.Marshal(Typed{tm})
+	assert(string(data) == `{"t":"time.Time","v":"2024-05-01T10:30:00.000000123Z","l":"2006-01-02"}`, string(data))
+	data, _ = json.Marshal(Typed{New(time.Date(2024, 5, 1, 10, 30, 0, 0, time.FixedZone("Z0", 0)))})
+	assert(string(data) == `{"t":"time.Time","v":"2024-05-01T10:30:00Z","z":0}`, string(data))
+	data, _ = json.Marshal(Typed{New(time.Date(2024, 5, 1, 10, 30, 0, 0, time.FixedZone("", 3600)))})
+	assert(string(data) == `{"t":"time.Time","v":"2024-05-01T10:30:00+01:00"}`, string(data))
+	data, _ = json.Marshal(Typed{NewList(uint8(1), New(null))})
+	assert(string(data) == `{"t":"list","v":[{"t":"uint8","v":1},{"t":"int16","v":null}]}`, string(data))
+
+	// map entries keep their order
+	m = NewMap()
+	m.Set("z", 1).Set("a", 2)
+	data, _ = json.Marshal(Typed{m})
+	var got Typed
+	assert(json.Unmarshal(data, &got) == nil)
+	assert(got.Keys()[0] == "z" && got.Get("a").Type == Int)
+
+	for _, bad := range []string{
+		`{"t":"int8","v":300}`,
+		`{"t":"nope","v":1}`,
+		`{"t":"time.Time","v":"yesterday"}`,
+		`{"t":"list","v":{}}`,
+		`[1]`,
+	} {
+		assert(json.Unmarshal([]byte(bad), &got) != nil, bad)
+	}
+}
@@ -1,219 +1,262 @@
 package variant
 
-import (
-	"encoding/binary"
-	"math"
-	"time"
-)
-
 var _ IConvertStrategy[uint] = (*uintConverter)(nil)
 
 type uintConverter struct {
 	Converter[uint]
 }
 
-func (u uintConverter) FromString(v Variant) uint {
-	tail := []byte{}
-	for index, ch := range v.Data {
-		if ch == '.' {
-			tail = v.Data[index+1:]
-			if len(tail) == 0 {
-				return 0
-			}
-			v.Data = v.Data[:index]
-			break
-		}
-	}
-	// check if tail is not a number
-	for _, ch := range tail {
-		if ch < '0' || ch > '9' {
-			return 0
-		}
-	}
-
-	start := 0
-	sLen := len(v.Data)
-	if sLen == 0 || v.Data[0] == '-' {
-		return 0
-	}
-	if v.Data[0] == '+' {
-		start = 1
-		sLen -= 1
-		if len(v.Data) < 2 {
-			return 0
-		}
-	}
-
-	var n uint = 0
-
-	if (intSize == 32 && sLen < 10) || (intSize == 64 && sLen < 20) {
-		for _, ch := range v.Data[start:] {
-			ch -= '0'
-			if ch > 9 {
-				return 0
-			}
-			n = n*10 + uint(ch)
-		}
-	}
-
-	if (intSize == 32 && sLen == 10) || (intSize == 64 && sLen == 20) {
-		for _, ch := range v.Data[start : len(v.Data)-1] {
-			ch -= '0'
-			if ch > 9 {
-				return 0
-			}
-			n = n*10 + uint(ch)
-		}
-
-		var cutoff uint = maxUint / 10
-		var remainder uint = maxUint % 10
-		ch := v.Data[len(v.Data)-1] - '0'
-		if ch > 9 {
-			return 0
-		}
-		digit := uint(ch)
-		if (cutoff == n && remainder >= digit) || cutoff > n {
-			n = n*10 + digit
-		} else {
-			return 0
-		}
-	}
-	return n
-}
-
-func (u uintConverter) FromBool(v Variant) uint {
+func (u uintConverter) FromString(v Variant) (uint, error) {
+	s, err := v.numeral()
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	i, err := parseUint(s, intSize, v.options())
+	if err == ErrOverflow {
+		return saturate[uint](v, Uint, negative(s))
+	}
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	return uint(i), nil
+}
+
+func (u uintConverter) FromBool(v Variant) (uint, error) {
 	if len(v.Data) == 0 || v.Data[0] == 0x00 {
-		return 0
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func (u uintConverter) FromInt(v Variant) (uint, error) {
+	i, err := payloadInt(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	if i < 0 || uint64(i) > maxUint {
+		return saturate[uint](v, Uint, i < 0)
+	}
+	return uint(i), nil
+}
+
+func (u uintConverter) FromInt8(v Variant) (uint, error) {
+	i, err := payloadInt8(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	if i < 0 {
+		return saturate[uint](v, Uint, true)
+	}
+	return uint(i), nil
+}
+
+func (u uintConverter) FromInt16(v Variant) (uint, error) {
+	i, err := payloadInt16(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	if i < 0 {
+		return saturate[uint](v, Uint, true)
+	}
+	return uint(i), nil
+}
+
+func (u uintConverter) FromInt32(v Variant) (uint, error) {
+	i, err := payloadInt32(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	if i < 0 {
+		return saturate[uint](v, Uint, true)
+	}
+	return uint(i), nil
+}
+
+func (u uintConverter) FromInt64(v Variant) (uint, error) {
+	i, err := payloadInt64(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	if i < 0 || uint64(i) > maxUint {
+		return saturate[uint](v, Uint, i < 0)
 	}
-	return 1
+	return uint(i), nil
 }
 
-func (u uintConverter) FromInt(v Variant) uint {
-	var i int
-	if len(v.Data) == 4 && intSize == 32 {
-		i = int(binary.BigEndian.Uint32(v.Data))
-	} else if len(v.Data) == 8 && intSize == 64 {
-		i = int(binary.BigEndian.Uint64(v.Data))
+func (u uintConverter) FromUint(v Variant) (uint, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	if i > maxUint {
+		return saturate[uint](v, Uint, false)
 	}
-	if i <= 0 {
-		return 0
+	return uint(i), nil
+}
+
+func (u uintConverter) FromUint8(v Variant) (uint, error) {
+	i, err := payloadUint8(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
 	}
-	return uint(i)
+	return uint(i), nil
 }
 
-func (u uintConverter) FromInt8(v Variant) uint {
-	var i int8
-	if len(v.Data) == 1 {
-		i = int8(v.Data[0])
+func (u uintConverter) FromUint16(v Variant) (uint, error) {
+	i, err := payloadUint16(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
 	}
-	if i <= 0 {
-		return 0
+	return uint(i), nil
+}
+
+func (u uintConverter) FromUint32(v Variant) (uint, error) {
+	i, err := payloadUint32(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
 	}
-	return uint(i)
+	return uint(i), nil
 }
 
-func (u uintConverter) FromInt16(v Variant) uint {
-	var i int16
-	if len(v.Data) == 2 {
-		i = int16(binary.BigEndian.Uint16(v.Data))
+func (u uintConverter) FromUint64(v Variant) (uint, error) {
+	i, err := payloadUint64(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
 	}
-	if i <= 0 {
-		return 0
+	if i > maxUint {
+		return saturate[uint](v, Uint, false)
 	}
-	return uint(i)
+	return uint(i), nil
 }
 
-func (u uintConverter) FromInt32(v Variant) uint {
-	i := int32(binary.BigEndian.Uint32(v.Data))
-	if i <= 0 {
-		return 0
+func (u uintConverter) FromUintptr(v Variant) (uint, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	if i > maxUint {
+		return saturate[uint](v, Uint, false)
 	}
-	return uint(i)
+	return uint(i), nil
 }
 
-func (u uintConverter) FromInt64(v Variant) uint {
-	i := int(binary.BigEndian.Uint64(v.Data))
-	if i <= 0 {
-		return 0
+func (u uintConverter) FromFloat32(v Variant) (uint, error) {
+	f, err := payloadFloat32(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
 	}
-	return uint(i)
+	return floatInt[uint](v, Uint, float64(f))
 }
 
-func (u uintConverter) FromUint(v Variant) uint {
-	var i uint
-	if intSize == 32 {
-		i = uint(binary.BigEndian.Uint32(v.Data))
-	} else if intSize == 64 {
-		i = uint(binary.BigEndian.Uint64(v.Data))
+func (u uintConverter) FromFloat64(v Variant) (uint, error) {
+	f, err := payloadFloat64(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
 	}
-	return i
+	return floatInt[uint](v, Uint, f)
 }
 
-func (u uintConverter) FromUint8(v Variant) uint {
-	return uint(v.Data[0])
+func (u uintConverter) FromComplex64(v Variant) (uint, error) {
+	z, err := payloadComplex64(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	return complexInt[uint](v, Uint, complex128(z))
 }
 
-func (u uintConverter) FromUint16(v Variant) uint {
-	return uint(binary.BigEndian.Uint16(v.Data))
+func (u uintConverter) FromComplex128(v Variant) (uint, error) {
+	z, err := payloadComplex128(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	return complexInt[uint](v, Uint, z)
 }
 
-func (u uintConverter) FromUint32(v Variant) uint {
-	return uint(binary.BigEndian.Uint32(v.Data))
+func (u uintConverter) FromTime(v Variant) (uint, error) {
+	t, err := payloadTime(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	n := epochNumber(v, t)
+	if n < 0 || uint64(n) > maxUint {
+		return saturate[uint](v, Uint, n < 0)
+	}
+	return uint(n), nil
 }
 
-func (u uintConverter) FromUint64(v Variant) uint {
-	return uint(binary.BigEndian.Uint64(v.Data))
+func (u uintConverter) FromDuration(v Variant) (uint, error) {
+	d, err := payloadDuration(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	n, err := durationNumber(v, d)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
+	}
+	if n < 0 || uint64(n) > maxUint {
+		return saturate[uint](v, Uint, n < 0)
+	}
+	return uint(n), nil
 }
 
-func (u uintConverter) FromFloat32(v Variant) uint {
-	var f float32
-	if len(v.Data) == 4 {
-		f = math.Float32frombits(binary.BigEndian.Uint32(v.Data))
+func (u uintConverter) FromBigInt(v Variant) (uint, error) {
+	b, err := payloadBigInt(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
 	}
-	if f < 0 || f > float32(maxUint) {
-		return 0
+	if !b.IsUint64() || uint64(uint(b.Uint64())) != b.Uint64() {
+		return saturate[uint](v, Uint, b.Sign() < 0)
 	}
-	return uint(f)
+	return uint(b.Uint64()), nil
 }
 
-func (u uintConverter) FromFloat64(v Variant) uint {
-	var f float64
-	if len(v.Data) == 8 {
-		f = math.Float64frombits(binary.BigEndian.Uint64(v.Data))
+func (u uintConverter) FromBigFloat(v Variant) (uint, error) {
+	b, err := payloadBigFloatInt(v)
+	if err != nil {
+		return 0, conversionError(v, Uint, err)
 	}
-	if f < 0 || f > float64(maxUint) {
-		return 0
+	if !b.IsUint64() || uint64(uint(b.Uint64())) != b.Uint64() {
+		return saturate[uint](v, Uint, b.Sign() < 0)
 	}
-	return uint(f)
+	return uint(b.Uint64()), nil
 }
 
-func (u uintConverter) FromTime(v Variant) uint {
-	var t time.Time
-	err := t.UnmarshalBinary(v.Data)
+func (u uintConverter) FromDecimal(v Variant) (uint, error) {
+	b, err := payloadDecInt(v)
 	if err != nil {
-		return 0
+		return 0, conversionError(v, Uint, err)
+	}
+	if !b.IsUint64() || uint64(uint(b.Uint64())) != b.Uint64() {
+		return saturate[uint](v, Uint, b.Sign() < 0)
 	}
-	return uint(t.UnixNano())
+	return uint(b.Uint64()), nil
 }
 
 func newUintConverter() IConvertStrategy[uint] {
 	c := &uintConverter{}
-	c.m = map[Kind]func(v Variant) uint{
-		String:  c.FromString,
-		Bool:    c.FromBool,
-		Int:     c.FromInt,
-		Int8:    c.FromInt8,
-		Int16:   c.FromInt16,
-		Int32:   c.FromInt32,
-		Int64:   c.FromInt64,
-		Uint:    c.FromUint,
-		Uint8:   c.FromUint8,
-		Uint16:  c.FromUint16,
-		Uint32:  c.FromUint32,
-		Uint64:  c.FromUint64,
-		Float32: c.FromFloat32,
-		Float64: c.FromFloat64,
-		Time:    c.FromTime,
+	c.m = map[Kind]func(v Variant) (uint, error){
+		String:     c.FromString,
+		Bool:       c.FromBool,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Time:       c.FromTime,
+		Duration:   c.FromDuration,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
 	}
 	return c
 }
@@ -1,218 +1,253 @@
 package variant
 
-import (
-	"encoding/binary"
-	"math"
-	"time"
-)
-
 var _ IConvertStrategy[uint64] = (*uint64Converter)(nil)
 
 type uint64Converter struct {
 	Converter[uint64]
 }
 
-func (u uint64Converter) FromString(v Variant) uint64 {
-	tail := []byte{}
-	for index, ch := range v.Data {
-		if ch == '.' {
-			tail = v.Data[index+1:]
-			if len(tail) == 0 {
-				return 0
-			}
-			v.Data = v.Data[:index]
-			break
-		}
-	}
-	// check if tail is not a number
-	for _, ch := range tail {
-		if ch < '0' || ch > '9' {
-			return 0
-		}
-	}
-	start := 0
-	sLen := len(v.Data)
-	if sLen == 0 || v.Data[0] == '-' {
-		return 0
-	}
-	if v.Data[0] == '+' {
-		start = 1
-		sLen -= 1
-		if len(v.Data) < 2 {
-			return 0
-		}
-	}
-
-	var n uint64 = 0
-
-	if (intSize == 32 && sLen < 10) || (intSize == 64 && sLen < 20) {
-		for _, ch := range v.Data[start:] {
-			ch -= '0'
-			if ch > 9 {
-				return 0
-			}
-			n = n*10 + uint64(ch)
-		}
-	}
-
-	if (intSize == 32 && sLen == 10) || (intSize == 64 && sLen == 20) {
-		for _, ch := range v.Data[start : len(v.Data)-1] {
-			ch -= '0'
-			if ch > 9 {
-				return 0
-			}
-			n = n*10 + uint64(ch)
-		}
-
-		var cutoff uint64 = maxUint / 10
-		var remainder uint64 = maxUint % 10
-		ch := v.Data[len(v.Data)-1] - '0'
-		if ch > 9 {
-			return 0
-		}
-		digit := uint64(ch)
-		if (cutoff == n && remainder >= digit) || cutoff > n {
-			n = n*10 + digit
-		} else {
-			return 0
-		}
-	}
-	return n
-}
-
-func (u uint64Converter) FromBool(v Variant) uint64 {
+func (u uint64Converter) FromString(v Variant) (uint64, error) {
+	s, err := v.numeral()
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
+	}
+	i, err := parseUint(s, 64, v.options())
+	if err == ErrOverflow {
+		return saturate[uint64](v, Uint64, negative(s))
+	}
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
+	}
+	return i, nil
+}
+
+func (u uint64Converter) FromBool(v Variant) (uint64, error) {
 	if len(v.Data) == 0 || v.Data[0] == 0x00 {
-		return 0
+		return 0, nil
 	}
-	return 1
+	return 1, nil
 }
 
-func (u uint64Converter) FromInt(v Variant) uint64 {
-	var i int
-	if len(v.Data) == 4 && intSize == 32 {
-		i = int(binary.BigEndian.Uint32(v.Data))
-	} else if len(v.Data) == 8 && intSize == 64 {
-		i = int(binary.BigEndian.Uint64(v.Data))
+func (u uint64Converter) FromInt(v Variant) (uint64, error) {
+	i, err := payloadInt(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
 	}
-	if i <= 0 {
-		return 0
+	if i < 0 {
+		return saturate[uint64](v, Uint64, true)
 	}
-	return uint64(i)
+	return uint64(i), nil
 }
 
-func (u uint64Converter) FromInt8(v Variant) uint64 {
-	var i int8
-	if len(v.Data) == 1 {
-		i = int8(v.Data[0])
+func (u uint64Converter) FromInt8(v Variant) (uint64, error) {
+	i, err := payloadInt8(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
 	}
-	if i <= 0 {
-		return 0
+	if i < 0 {
+		return saturate[uint64](v, Uint64, true)
 	}
-	return uint64(i)
+	return uint64(i), nil
 }
 
-func (u uint64Converter) FromInt16(v Variant) uint64 {
-	var i int16
-	if len(v.Data) == 2 {
-		i = int16(binary.BigEndian.Uint16(v.Data))
+func (u uint64Converter) FromInt16(v Variant) (uint64, error) {
+	i, err := payloadInt16(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
 	}
-	if i <= 0 {
-		return 0
+	if i < 0 {
+		return saturate[uint64](v, Uint64, true)
 	}
-	return uint64(i)
+	return uint64(i), nil
 }
 
-func (u uint64Converter) FromInt32(v Variant) uint64 {
-	i := int32(binary.BigEndian.Uint32(v.Data))
-	if i <= 0 {
-		return 0
+func (u uint64Converter) FromInt32(v Variant) (uint64, error) {
+	i, err := payloadInt32(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
 	}
-	return uint64(i)
+	if i < 0 {
+		return saturate[uint64](v, Uint64, true)
+	}
+	return uint64(i), nil
 }
 
-func (u uint64Converter) FromInt64(v Variant) uint64 {
-	i := binary.BigEndian.Uint64(v.Data)
-	if int(i) <= 0 {
-		return 0
+func (u uint64Converter) FromInt64(v Variant) (uint64, error) {
+	i, err := payloadInt64(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
+	}
+	if i < 0 {
+		return saturate[uint64](v, Uint64, true)
 	}
-	return i
+	return uint64(i), nil
 }
 
-func (u uint64Converter) FromUint(v Variant) uint64 {
-	var i uint64
-	if intSize == 32 {
-		i = uint64(binary.BigEndian.Uint32(v.Data))
-	} else if intSize == 64 {
-		i = binary.BigEndian.Uint64(v.Data)
+func (u uint64Converter) FromUint(v Variant) (uint64, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
 	}
-	return i
+	return uint64(i), nil
 }
 
-func (u uint64Converter) FromUint8(v Variant) uint64 {
-	return uint64(v.Data[0])
+func (u uint64Converter) FromUint8(v Variant) (uint64, error) {
+	i, err := payloadUint8(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
+	}
+	return uint64(i), nil
 }
 
-func (u uint64Converter) FromUint16(v Variant) uint64 {
-	return uint64(binary.BigEndian.Uint16(v.Data))
+func (u uint64Converter) FromUint16(v Variant) (uint64, error) {
+	i, err := payloadUint16(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
+	}
+	return uint64(i), nil
 }
 
-func (u uint64Converter) FromUint32(v Variant) uint64 {
-	return uint64(binary.BigEndian.Uint32(v.Data))
+func (u uint64Converter) FromUint32(v Variant) (uint64, error) {
+	i, err := payloadUint32(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
+	}
+	return uint64(i), nil
 }
 
-func (u uint64Converter) FromUint64(v Variant) uint64 {
-	return binary.BigEndian.Uint64(v.Data)
+func (u uint64Converter) FromUint64(v Variant) (uint64, error) {
+	i, err := payloadUint64(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
+	}
+	return i, nil
 }
 
-func (u uint64Converter) FromFloat32(v Variant) uint64 {
-	var f float32
-	if len(v.Data) == 4 {
-		f = math.Float32frombits(binary.BigEndian.Uint32(v.Data))
+func (u uint64Converter) FromUintptr(v Variant) (uint64, error) {
+	i, err := payloadUint(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
 	}
-	if f < 0 || f > float32(maxUint) {
-		return 0
+	return uint64(i), nil
+}
+
+func (u uint64Converter) FromFloat32(v Variant) (uint64, error) {
+	f, err := payloadFloat32(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
 	}
-	return uint64(f)
+	return floatInt[uint64](v, Uint64, float64(f))
 }
 
-func (u uint64Converter) FromFloat64(v Variant) uint64 {
-	var f float64
-	if len(v.Data) == 8 {
-		f = math.Float64frombits(binary.BigEndian.Uint64(v.Data))
+func (u uint64Converter) FromFloat64(v Variant) (uint64, error) {
+	f, err := payloadFloat64(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
 	}
-	if f < 0 || f > float64(maxUint) {
-		return 0
+	return floatInt[uint64](v, Uint64, f)
+}
+
+func (u uint64Converter) FromComplex64(v Variant) (uint64, error) {
+	z, err := payloadComplex64(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
+	}
+	return complexInt[uint64](v, Uint64, complex128(z))
+}
+
+func (u uint64Converter) FromComplex128(v Variant) (uint64, error) {
+	z, err := payloadComplex128(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
 	}
-	return uint64(f)
+	return complexInt[uint64](v, Uint64, z)
 }
 
-func (u uint64Converter) FromTime(v Variant) uint64 {
-	var t time.Time
-	err := t.UnmarshalBinary(v.Data)
+func (u uint64Converter) FromTime(v Variant) (uint64, error) {
+	t, err := payloadTime(v)
 	if err != nil {
-		return 0
+		return 0, conversionError(v, Uint64, err)
+	}
+	n := epochNumber(v, t)
+	if n < 0 {
+		return saturate[uint64](v, Uint64, true)
+	}
+	return uint64(n), nil
+}
+
+func (u uint64Converter) FromDuration(v Variant) (uint64, error) {
+	d, err := payloadDuration(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
+	}
+	n, err := durationNumber(v, d)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
+	}
+	if n < 0 {
+		return saturate[uint64](v, Uint64, true)
+	}
+	return uint64(n), nil
+}
+
+func (u uint64Converter) FromBigInt(v Variant) (uint64, error) {
+	b, err := payloadBigInt(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
+	}
+	if !b.IsUint64() {
+		return saturate[uint64](v, Uint64, b.Sign() < 0)
+	}
+	return b.Uint64(), nil
+}
+
+func (u uint64Converter) FromBigFloat(v Variant) (uint64, error) {
+	b, err := payloadBigFloatInt(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
+	}
+	if !b.IsUint64() {
+		return saturate[uint64](v, Uint64, b.Sign() < 0)
+	}
+	return b.Uint64(), nil
+}
+
+func (u uint64Converter) FromDecimal(v Variant) (uint64, error) {
+	b, err := payloadDecInt(v)
+	if err != nil {
+		return 0, conversionError(v, Uint64, err)
+	}
+	if !b.IsUint64() {
+		return saturate[uint64](v, Uint64, b.Sign() < 0)
 	}
-	return uint64(t.UnixNano())
+	return b.Uint64(), nil
 }
 
 func newUint64Converter() IConvertStrategy[uint64] {
 	c := &uint64Converter{}
-	c.m = map[Kind]func(v Variant) uint64{
-		String:  c.FromString,
-		Bool:    c.FromBool,
-		Int:     c.FromInt,
-		Int8:    c.FromInt8,
-		Int16:   c.FromInt16,
-		Int32:   c.FromInt32,
-		Int64:   c.FromInt64,
-		Uint:    c.FromUint,
-		Uint8:   c.FromUint8,
-		Uint16:  c.FromUint16,
-		Uint32:  c.FromUint32,
-		Uint64:  c.FromUint64,
-		Float32: c.FromFloat32,
-		Float64: c.FromFloat64,
-		Time:    c.FromTime,
+	c.m = map[Kind]func(v Variant) (uint64, error){
+		String:     c.FromString,
+		Bool:       c.FromBool,
+		Int:        c.FromInt,
+		Int8:       c.FromInt8,
+		Int16:      c.FromInt16,
+		Int32:      c.FromInt32,
+		Int64:      c.FromInt64,
+		Uint:       c.FromUint,
+		Uint8:      c.FromUint8,
+		Uint16:     c.FromUint16,
+		Uint32:     c.FromUint32,
+		Uint64:     c.FromUint64,
+		Uintptr:    c.FromUintptr,
+		Float32:    c.FromFloat32,
+		Float64:    c.FromFloat64,
+		Complex64:  c.FromComplex64,
+		Complex128: c.FromComplex128,
+		Time:       c.FromTime,
+		Duration:   c.FromDuration,
+		BigInt:     c.FromBigInt,
+		BigFloat:   c.FromBigFloat,
+		Decimal:    c.FromDecimal,
 	}
 	return c
 }
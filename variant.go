@@ -1,10 +1,13 @@
 package variant
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
+	"slices"
 	"time"
 )
 
@@ -18,10 +21,31 @@ type Variant struct {
 	Type   Kind
 	Data   []byte
 	layout string
+	list   []Variant // elements of a List
+	end    *int      // length of list after the last Append, see Append
+	obj    *object   // entries of a Map
+	null   bool      // a typed null, Type is the declared kind
+	policy *Policy   // the policy converting the variant, see With
 }
 
 var Nil = Variant{Type: Invalid}
 
+// NewNull returns a null variant of the kind k, e.g. the value of a nil *int64
+// or of a NULL in an integer column. NewNull(Invalid) is an untyped null.
+func NewNull(k Kind) Variant {
+	return Variant{Type: k, layout: time.DateTime, null: k != Invalid}
+}
+
+// IsNull reports whether v is null: an Invalid variant or a typed null.
+func (v Variant) IsNull() bool {
+	return v.null || v.Type == Invalid
+}
+
+// IsValid reports whether v has a kind, even if it is a typed null.
+func (v Variant) IsValid() bool {
+	return v.Type != Invalid
+}
+
 func (v *Variant) SetLayout(layout string) *Variant {
 	v.layout = layout
 	return v
@@ -29,169 +53,580 @@ func (v *Variant) SetLayout(layout string) *Variant {
 
 // Implement Stringer interface
 func (v Variant) String() string {
+	if v.null {
+		return fmt.Sprintf("Variant(%v, null)", v.Type)
+	}
+	switch v.Type {
+	case List:
+		return fmt.Sprintf("Variant(%v, %v)", v.Type, v.list)
+	case Map:
+		return fmt.Sprintf("Variant(%v, %v)", v.Type, v.obj.entries())
+	}
 	return fmt.Sprintf("Variant(%v, %v)", v.Type, v.Data)
 }
 
-// ToBytes converts the Variant to a byte slice.
+// ToBytes converts the Variant to a byte slice: the bytes of a String or
+// Bytes variant, the text form of any other kind.
 func (v Variant) ToBytes() []byte {
-	return v.Data
+	r, _ := v.TryBytes()
+	return r
+}
+
+// TryBytes converts the Variant to a byte slice, reporting a *ConversionError
+// when the conversion fails.
+func (v Variant) TryBytes() ([]byte, error) {
+	return convert(Strategies.bytes, Bytes, v)
+}
+
+// DecodeBytes decodes a String variant with enc, e.g. base64.StdEncoding or
+// HexEncoding. Any other kind is converted as by TryBytes.
+func (v Variant) DecodeBytes(enc Encoding) ([]byte, error) {
+	if v.Type != String {
+		return v.TryBytes()
+	}
+	b, err := enc.DecodeString(string(v.Data))
+	if err != nil {
+		return nil, conversionError(v, Bytes, ErrSyntax)
+	}
+	return b, nil
 }
 
 // ToBool converts the Variant to a boolean value.
 func (v Variant) ToBool() bool {
-	for _, ch := range v.Data {
-		if ch != 0 {
-			return true
-		}
-	}
-	return false
+	r, _ := v.TryBool()
+	return r
+}
+
+// TryBool converts the Variant to a boolean value, failing with
+// ErrUnsupported for an Invalid variant.
+func (v Variant) TryBool() (bool, error) {
+	return convert(Strategies.bool, Bool, v)
 }
 
 // ToInt converts the Variant to an int value.
 func (v Variant) ToInt() int {
-	if fn := Strategies.int.Get(v.Type); fn != nil {
-		return fn(v)
-	}
-	return 0
+	r, _ := v.TryInt()
+	return r
+}
+
+// TryInt converts the Variant to an int value, reporting a *ConversionError
+// when the conversion fails.
+func (v Variant) TryInt() (int, error) {
+	return convert(Strategies.int, Int, v)
+}
+
+// ToInt8 converts the Variant to an int8 value.
+func (v Variant) ToInt8() int8 {
+	r, _ := v.TryInt8()
+	return r
+}
+
+// TryInt8 converts the Variant to an int8 value, reporting a *ConversionError
+// when the conversion fails or the value does not fit in an int8.
+func (v Variant) TryInt8() (int8, error) {
+	return narrowInt[int8](v, Int8)
+}
+
+// ToInt16 converts the Variant to an int16 value.
+func (v Variant) ToInt16() int16 {
+	r, _ := v.TryInt16()
+	return r
+}
+
+// TryInt16 converts the Variant to an int16 value, reporting a *ConversionError
+// when the conversion fails or the value does not fit in an int16.
+func (v Variant) TryInt16() (int16, error) {
+	return narrowInt[int16](v, Int16)
+}
+
+// ToInt32 converts the Variant to an int32 value.
+func (v Variant) ToInt32() int32 {
+	r, _ := v.TryInt32()
+	return r
+}
+
+// TryInt32 converts the Variant to an int32 value, reporting a *ConversionError
+// when the conversion fails or the value does not fit in an int32.
+func (v Variant) TryInt32() (int32, error) {
+	return narrowInt[int32](v, Int32)
 }
 
 // ToInt64 converts the Variant to an int64 value.
 func (v Variant) ToInt64() int64 {
-	if fn := Strategies.int64.Get(v.Type); fn != nil {
-		return fn(v)
-	}
-	return 0
+	r, _ := v.TryInt64()
+	return r
+}
+
+// TryInt64 converts the Variant to an int64 value, reporting a *ConversionError
+// when the conversion fails.
+func (v Variant) TryInt64() (int64, error) {
+	return convert(Strategies.int64, Int64, v)
 }
 
 // ToUint converts the Variant to an uint value.
 func (v Variant) ToUint() uint {
-	if fn := Strategies.uint.Get(v.Type); fn != nil {
-		return fn(v)
-	}
-	return 0
+	r, _ := v.TryUint()
+	return r
+}
+
+// TryUint converts the Variant to an uint value, reporting a *ConversionError
+// when the conversion fails.
+func (v Variant) TryUint() (uint, error) {
+	return convert(Strategies.uint, Uint, v)
+}
+
+// ToUint8 converts the Variant to an uint8 value.
+func (v Variant) ToUint8() uint8 {
+	r, _ := v.TryUint8()
+	return r
+}
+
+// TryUint8 converts the Variant to an uint8 value, reporting a *ConversionError
+// when the conversion fails or the value does not fit in an uint8.
+func (v Variant) TryUint8() (uint8, error) {
+	return narrowUint[uint8](v, Uint8)
+}
+
+// ToUint16 converts the Variant to an uint16 value.
+func (v Variant) ToUint16() uint16 {
+	r, _ := v.TryUint16()
+	return r
+}
+
+// TryUint16 converts the Variant to an uint16 value, reporting a *ConversionError
+// when the conversion fails or the value does not fit in an uint16.
+func (v Variant) TryUint16() (uint16, error) {
+	return narrowUint[uint16](v, Uint16)
+}
+
+// ToUint32 converts the Variant to an uint32 value.
+func (v Variant) ToUint32() uint32 {
+	r, _ := v.TryUint32()
+	return r
+}
+
+// TryUint32 converts the Variant to an uint32 value, reporting a *ConversionError
+// when the conversion fails or the value does not fit in an uint32.
+func (v Variant) TryUint32() (uint32, error) {
+	return narrowUint[uint32](v, Uint32)
 }
 
 // ToUint64 converts the Variant to an uint64 value.
 func (v Variant) ToUint64() uint64 {
-	if fn := Strategies.uint64.Get(v.Type); fn != nil {
-		return fn(v)
-	}
-	return 0
+	r, _ := v.TryUint64()
+	return r
+}
+
+// TryUint64 converts the Variant to an uint64 value, reporting a *ConversionError
+// when the conversion fails.
+func (v Variant) TryUint64() (uint64, error) {
+	return convert(Strategies.uint64, Uint64, v)
+}
+
+// ToUintptr converts the Variant to an uintptr value.
+func (v Variant) ToUintptr() uintptr {
+	r, _ := v.TryUintptr()
+	return r
+}
+
+// TryUintptr converts the Variant to an uintptr value, reporting a
+// *ConversionError when the conversion fails or the value does not fit in an
+// uintptr.
+func (v Variant) TryUintptr() (uintptr, error) {
+	return narrowUint[uintptr](v, Uintptr)
 }
 
 // ToFloat32 converts the Variant to a float32 value.
 func (v Variant) ToFloat32() float32 {
-	if fn := Strategies.float32.Get(v.Type); fn != nil {
-		return fn(v)
-	}
-	return 0
+	r, _ := v.TryFloat32()
+	return r
+}
+
+// TryFloat32 converts the Variant to a float32 value, reporting a *ConversionError
+// when the conversion fails.
+func (v Variant) TryFloat32() (float32, error) {
+	return convert(Strategies.float32, Float32, v)
 }
 
 // ToFloat64 converts the Variant to a float64 value.
 func (v Variant) ToFloat64() float64 {
-	if fn := Strategies.float64.Get(v.Type); fn != nil {
-		return fn(v)
-	}
-	return 0
+	r, _ := v.TryFloat64()
+	return r
+}
+
+// TryFloat64 converts the Variant to a float64 value, reporting a *ConversionError
+// when the conversion fails.
+func (v Variant) TryFloat64() (float64, error) {
+	return convert(Strategies.float64, Float64, v)
+}
+
+// ToComplex64 converts the Variant to a complex64 value.
+func (v Variant) ToComplex64() complex64 {
+	r, _ := v.TryComplex64()
+	return r
+}
+
+// TryComplex64 converts the Variant to a complex64 value, reporting a
+// *ConversionError when the conversion fails.
+func (v Variant) TryComplex64() (complex64, error) {
+	return convert(Strategies.complex64, Complex64, v)
+}
+
+// ToComplex128 converts the Variant to a complex128 value.
+func (v Variant) ToComplex128() complex128 {
+	r, _ := v.TryComplex128()
+	return r
+}
+
+// TryComplex128 converts the Variant to a complex128 value, reporting a
+// *ConversionError when the conversion fails.
+func (v Variant) TryComplex128() (complex128, error) {
+	return convert(Strategies.complex128, Complex128, v)
 }
 
 // ToString converts the Variant to a string value.
 func (v Variant) ToString() string {
+	r, _ := v.TryString()
+	return r
+}
 
-	if fn := Strategies.string.Get(v.Type); fn != nil {
-		return fn(v)
-	}
-	return ""
+// TryString converts the Variant to a string value, reporting a *ConversionError
+// when the conversion fails.
+func (v Variant) TryString() (string, error) {
+	return convert(Strategies.string, String, v)
 }
 
 // ToTime converts the Variant to a time.Time value.
 func (v Variant) ToTime() time.Time {
-	if fn := Strategies.time.Get(v.Type); fn != nil {
-		return fn(v)
-	}
-	return time.Time{}
+	r, _ := v.TryTime()
+	return r
+}
+
+// TryTime converts the Variant to a time.Time value, reporting a *ConversionError
+// when the conversion fails.
+func (v Variant) TryTime() (time.Time, error) {
+	return convert(Strategies.time, Time, v)
+}
+
+// ToDuration converts the Variant to a time.Duration value.
+func (v Variant) ToDuration() time.Duration {
+	r, _ := v.TryDuration()
+	return r
+}
+
+// TryDuration converts the Variant to a time.Duration value, reporting a
+// *ConversionError when the conversion fails.
+func (v Variant) TryDuration() (time.Duration, error) {
+	return convert(Strategies.duration, Duration, v)
+}
+
+// ToBigInt converts the Variant to a *big.Int value, or nil when the
+// conversion fails.
+func (v Variant) ToBigInt() *big.Int {
+	r, _ := v.TryBigInt()
+	return r
+}
+
+// TryBigInt converts the Variant to a *big.Int value, reporting a
+// *ConversionError when the conversion fails.
+func (v Variant) TryBigInt() (*big.Int, error) {
+	return convert(Strategies.bigInt, BigInt, v)
 }
 
-// Equal checks if the Variant is equal to another value.
+// ToBigFloat converts the Variant to a *big.Float value, or nil when the
+// conversion fails.
+func (v Variant) ToBigFloat() *big.Float {
+	r, _ := v.TryBigFloat()
+	return r
+}
+
+// TryBigFloat converts the Variant to a *big.Float value, reporting a
+// *ConversionError when the conversion fails.
+func (v Variant) TryBigFloat() (*big.Float, error) {
+	return convert(Strategies.bigFloat, BigFloat, v)
+}
+
+// ToDecimal converts the Variant to a Dec value, or zero when the conversion
+// fails.
+func (v Variant) ToDecimal() Dec {
+	r, _ := v.TryDecimal()
+	return r
+}
+
+// TryDecimal converts the Variant to a Dec value, reporting a
+// *ConversionError when the conversion fails.
+func (v Variant) TryDecimal() (Dec, error) {
+	return convert(Strategies.decimal, Decimal, v)
+}
+
+// Equal checks if the Variant is equal to another value. The entries of Map
+// variants are compared regardless of their order, BigFloat variants
+// regardless of their precision. Integers, floats and BigInts of different
+// kinds are compared by value, e.g. the Int64 a JSON number 36 decodes to
+// equals 36, while float32(0.1) differs from 0.1.
 func (v Variant) Equal(other any) bool {
-	if _, ok := other.(Variant); ok {
-		return reflect.DeepEqual(v, other)
+	o, ok := other.(Variant)
+	if !ok {
+		o = New(other)
+		o.layout = v.layout
+	}
+	return compare(v, o, true)
+}
+
+// equal reports whether a and b are the same variant, of the same kind.
+func equal(a, b Variant) bool {
+	return compare(a, b, false)
+}
+
+// compare reports whether a and b hold the same value, numbers of different
+// kinds included if numeric.
+func compare(a, b Variant, numeric bool) bool {
+	if numeric && a.Type != b.Type && !a.null && !b.null && a.layout == b.layout {
+		if x, ok := numberValue(a); ok {
+			y, ok := numberValue(b)
+			return ok && x.Cmp(y) == 0
+		}
+	}
+	if a.Type != b.Type || a.layout != b.layout || a.null != b.null {
+		return false
+	}
+	switch a.Type {
+	case List:
+		return slices.EqualFunc(a.list, b.list, func(x, y Variant) bool {
+			return compare(x, y, numeric)
+		})
+	case Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.Keys() {
+			if bv, ok := b.obj.m[key]; !ok || !compare(a.obj.m[key], bv, numeric) {
+				return false
+			}
+		}
+		return true
+	case BigFloat:
+		// the payload also records the precision, compare the values only
+		x, errX := payloadBigFloat(a)
+		y, errY := payloadBigFloat(b)
+		if errX == nil && errY == nil {
+			return x.Cmp(y) == 0
+		}
 	}
-	variant := New(other)
-	variant.layout = v.layout
-	return reflect.DeepEqual(v, variant)
+	return bytes.Equal(a.Data, b.Data)
 }
 
+// numberValue returns the exact value of an integer, float or BigInt variant,
+// reporting false for other kinds and NaN.
+func numberValue(v Variant) (*big.Float, bool) {
+	switch v.Type {
+	case Int, Int8, Int16, Int32, Int64:
+		i, err := binaryInt(v)
+		return new(big.Float).SetInt64(i), err == nil
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		u, err := binaryUint(v)
+		return new(big.Float).SetUint64(u), err == nil
+	case Float32:
+		f, err := payloadFloat32(v)
+		return floatValue(float64(f), err)
+	case Float64:
+		return floatValue(payloadFloat64(v))
+	case BigInt:
+		b, err := payloadBigInt(v)
+		if err != nil {
+			return nil, false
+		}
+		return new(big.Float).SetInt(b), true
+	}
+	return nil, false
+}
+
+func floatValue(f float64, err error) (*big.Float, bool) {
+	if err != nil || math.IsNaN(f) {
+		return nil, false
+	}
+	return new(big.Float).SetFloat64(f), true
+}
+
+// New returns a variant holding v. Primitives, their pointers and the types
+// of this package are stored directly, any other value is encoded with
+// Encode, e.g. a named type like `type Status int`, a struct or a slice. A
+// value Encode cannot represent yields an Invalid variant, and so does an
+// element of a []any or map[string]any leading back to one nesting it.
 func New(v any) Variant {
+	if variant, ok := newVariant(v); ok {
+		return variant
+	}
+	variant, _ := Encode(v)
+	return variant
+}
+
+// newVariant returns v as a variant if it is one of the types New stores
+// directly, reporting false otherwise.
+func newVariant(v any) (Variant, bool) {
 	variant := Variant{
 		Type:   Invalid,
 		layout: time.DateTime,
 	}
 
 	switch v := v.(type) {
+	case Variant:
+		variant = v
+	case *Variant:
+		if v != nil {
+			variant = *v
+		}
 	case *string:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(String)
 		}
 	case *bool:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Bool)
 		}
 	case *int:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Int)
 		}
 	case *int8:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Int8)
 		}
 	case *int16:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Int16)
 		}
 	case *int32:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Int32)
 		}
 	case *int64:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Int64)
 		}
 	case *uint:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Uint)
 		}
 	case *uint8:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Uint8)
 		}
 	case *uint16:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Uint16)
 		}
 	case *uint32:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Uint32)
 		}
 	case *uint64:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Uint64)
 		}
 	case *float32:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Float32)
+		}
+	case *uintptr:
+		if v != nil {
+			variant = New(*v)
+		} else {
+			variant = NewNull(Uintptr)
 		}
 	case *float64:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Float64)
+		}
+	case *complex64:
+		if v != nil {
+			variant = New(*v)
+		} else {
+			variant = NewNull(Complex64)
+		}
+	case *complex128:
+		if v != nil {
+			variant = New(*v)
+		} else {
+			variant = NewNull(Complex128)
 		}
 	case *time.Time:
 		if v != nil {
 			variant = New(*v)
+		} else {
+			variant = NewNull(Time)
 		}
+	case *time.Duration:
+		if v != nil {
+			variant = New(*v)
+		} else {
+			variant = NewNull(Duration)
+		}
+	case *big.Int:
+		if v != nil {
+			variant.Type = BigInt
+			variant.Data, _ = v.GobEncode()
+		} else {
+			variant = NewNull(BigInt)
+		}
+	case *big.Float:
+		if v != nil {
+			variant.Type = BigFloat
+			variant.Data, _ = v.GobEncode()
+		} else {
+			variant = NewNull(BigFloat)
+		}
+	case *[]byte:
+		if v != nil {
+			variant = New(*v)
+		} else {
+			variant = NewNull(Bytes)
+		}
+	case *Dec:
+		if v != nil {
+			variant = New(*v)
+		} else {
+			variant = NewNull(Decimal)
+		}
+	case Dec:
+		variant.Type = Decimal
+		variant.Data = v.payload()
 	case string:
 		variant.Type = String
 		variant.Data = []byte(v)
+	case []byte:
+		if v != nil {
+			variant.Type = Bytes
+			variant.Data = bytes.Clone(v)
+		}
 	case bool:
 		variant.Type = Bool
 		if v {
@@ -250,6 +685,16 @@ func New(v any) Variant {
 		variant.Type = Uint64
 		variant.Data = make([]byte, 8)
 		binary.BigEndian.PutUint64(variant.Data, v)
+	case uintptr:
+		variant.Type = Uintptr
+		switch intSize {
+		case 32:
+			variant.Data = make([]byte, 4)
+			binary.BigEndian.PutUint32(variant.Data, uint32(v))
+		case 64:
+			variant.Data = make([]byte, 8)
+			binary.BigEndian.PutUint64(variant.Data, uint64(v))
+		}
 	case float32:
 		variant.Type = Float32
 		variant.Data = make([]byte, 4)
@@ -258,12 +703,95 @@ func New(v any) Variant {
 		variant.Type = Float64
 		variant.Data = make([]byte, 8)
 		binary.BigEndian.PutUint64(variant.Data, math.Float64bits(v))
+	case complex64:
+		variant.Type = Complex64
+		variant.Data = make([]byte, 8)
+		binary.BigEndian.PutUint32(variant.Data, math.Float32bits(real(v)))
+		binary.BigEndian.PutUint32(variant.Data[4:], math.Float32bits(imag(v)))
+	case complex128:
+		variant.Type = Complex128
+		variant.Data = make([]byte, 16)
+		binary.BigEndian.PutUint64(variant.Data, math.Float64bits(real(v)))
+		binary.BigEndian.PutUint64(variant.Data[8:], math.Float64bits(imag(v)))
 	case time.Time:
 		data, err := v.MarshalBinary()
 		if err == nil {
 			variant.Type = Time
 			variant.Data = data
 		}
+	case time.Duration:
+		variant.Type = Duration
+		variant.Data = make([]byte, 8)
+		binary.BigEndian.PutUint64(variant.Data, uint64(v))
+	case []Variant:
+		if v != nil {
+			variant.Type = List
+			variant.list = slices.Clone(v)
+		}
+	case []any:
+		if v != nil {
+			variant = newTree(v, nil)
+		}
+	case map[string]Variant:
+		if v != nil {
+			variant.Type = Map
+			variant.obj = newObject(len(v))
+			for _, key := range sortedKeys(v) {
+				variant.obj.set(key, v[key])
+			}
+		}
+	case map[string]any:
+		if v != nil {
+			variant = newTree(v, nil)
+		}
+	default:
+		return variant, false
+	}
+	return variant, true
+}
+
+// newTree returns v, a non-nil []any or map[string]any, as a List or a Map
+// variant of its elements. seen holds the slices and maps nesting v, an
+// element leading back to one of them is a cycle and yields an Invalid
+// variant.
+func newTree(v any, seen map[visit]struct{}) Variant {
+	key := visitOf(reflect.ValueOf(v))
+	if _, ok := seen[key]; ok {
+		return Nil
+	}
+	if seen == nil {
+		seen = make(map[visit]struct{})
+	}
+	seen[key] = struct{}{}
+	defer delete(seen, key)
+
+	elem := func(v any) Variant {
+		switch v := v.(type) {
+		case []any:
+			if v != nil {
+				return newTree(v, seen)
+			}
+		case map[string]any:
+			if v != nil {
+				return newTree(v, seen)
+			}
+		}
+		return New(v)
+	}
+	variant := Variant{layout: time.DateTime}
+	switch v := v.(type) {
+	case []any:
+		variant.Type = List
+		variant.list = make([]Variant, len(v))
+		for i := range v {
+			variant.list[i] = elem(v[i])
+		}
+	case map[string]any:
+		variant.Type = Map
+		variant.obj = newObject(len(v))
+		for _, key := range sortedKeys(v) {
+			variant.obj.set(key, elem(v[key]))
+		}
 	}
 	return variant
 }
@@ -234,6 +234,14 @@ func Test_Equal(t *testing.T) {
 	assert(!v1.Equal(1234))
 	assert(!v1.Equal(Nil))
 	assert(!v1.Equal(New(1234)))
+
+	// numbers of different kinds compare by value
+	assert(v1.Equal(int8(123)) && v1.Equal(uint64(123)) && v1.Equal(123.0))
+	assert(New(int64(-1)).Equal(-1) && !New(int64(-1)).Equal(uint64(math.MaxUint64)))
+	assert(!New(float32(0.1)).Equal(0.1) && New(float32(0.5)).Equal(0.5))
+	assert(!New(math.NaN()).Equal(float32(math.NaN())))
+	assert(!v1.Equal("123") && !v1.Equal(NewNull(Int64)))
+	assert(equal(New(123), New(123)) && !equal(New(123), New(int64(123))))
 }
 
 type Data3 struct {